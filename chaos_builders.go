@@ -0,0 +1,423 @@
+package chaosmesh
+
+import (
+	"strconv"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Directions of the packets affected by a network chaos with a target
+const (
+	To   = chaosmeshv1alpha1.To
+	From = chaosmeshv1alpha1.From
+	Both = chaosmeshv1alpha1.Both
+)
+
+// Network adds a NetworkChaos built from its fluent builder
+func (b *ChaosExperimentsBuilder) Network(name string, opts ...ExperimentOption) *NetworkChaosBuilder {
+	nb := &NetworkChaosBuilder{}
+	nb.obj.Name = name
+	b.add(&chaosExperiment{gvk: chaosmeshv1alpha1.GroupVersion.WithKind(chaosmeshv1alpha1.KindNetworkChaos), builder: nb.build}, opts)
+	return nb
+}
+
+// Pod adds a PodChaos built from its fluent builder
+func (b *ChaosExperimentsBuilder) Pod(name string, opts ...ExperimentOption) *PodChaosBuilder {
+	pb := &PodChaosBuilder{}
+	pb.obj.Name = name
+	b.add(&chaosExperiment{gvk: chaosmeshv1alpha1.GroupVersion.WithKind(chaosmeshv1alpha1.KindPodChaos), builder: pb.build}, opts)
+	return pb
+}
+
+// Stress adds a StressChaos built from its fluent builder
+func (b *ChaosExperimentsBuilder) Stress(name string, opts ...ExperimentOption) *StressChaosBuilder {
+	sb := &StressChaosBuilder{}
+	sb.obj.Name = name
+	b.add(&chaosExperiment{gvk: chaosmeshv1alpha1.GroupVersion.WithKind(chaosmeshv1alpha1.KindStressChaos), builder: sb.build}, opts)
+	return sb
+}
+
+// podSelection holds the pods selected by a builder, the namespace of the experiment is selected by default
+type podSelection struct {
+	namespaces []string
+	labels     map[string]string
+	mode       chaosmeshv1alpha1.SelectorMode
+	value      string
+}
+
+func (s *podSelection) withLabels(labels map[string]string) {
+	if s.labels == nil {
+		s.labels = map[string]string{}
+	}
+	for k, v := range labels {
+		s.labels[k] = v
+	}
+}
+
+func (s *podSelection) podSelector(namespace string) chaosmeshv1alpha1.PodSelector {
+	namespaces := s.namespaces
+	if len(namespaces) == 0 && namespace != "" {
+		namespaces = []string{namespace}
+	}
+	mode := s.mode
+	if mode == "" {
+		mode = chaosmeshv1alpha1.AllMode
+	}
+
+	return chaosmeshv1alpha1.PodSelector{
+		Mode:  mode,
+		Value: s.value,
+		Selector: chaosmeshv1alpha1.PodSelectorSpec{
+			GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
+				Namespaces:     namespaces,
+				LabelSelectors: s.labels,
+			},
+		},
+	}
+}
+
+// NetworkChaosBuilder builds a NetworkChaos, the action is set by the fault it injects
+type NetworkChaosBuilder struct {
+	obj       chaosmeshv1alpha1.NetworkChaos
+	selection podSelection
+	target    *podSelection
+	errs      []error
+
+	// jitter and correlation refine the fault, they are applied on build whatever the order of the calls
+	jitter      *time.Duration
+	correlation *float64
+}
+
+// InNamespace sets the namespace of the experiment, its pods are selected unless other namespaces are
+func (nb *NetworkChaosBuilder) InNamespace(namespace string) *NetworkChaosBuilder {
+	nb.obj.Namespace = namespace
+	return nb
+}
+
+// InNamespaces selects the pods of the given namespaces
+func (nb *NetworkChaosBuilder) InNamespaces(namespaces ...string) *NetworkChaosBuilder {
+	nb.selection.namespaces = namespaces
+	return nb
+}
+
+// WithLabels selects the pods with the given labels
+func (nb *NetworkChaosBuilder) WithLabels(labels map[string]string) *NetworkChaosBuilder {
+	nb.selection.withLabels(labels)
+	return nb
+}
+
+// Mode sets how many of the selected pods are injected, all of them by default
+func (nb *NetworkChaosBuilder) Mode(mode chaosmeshv1alpha1.SelectorMode, value ...string) *NetworkChaosBuilder {
+	nb.selection.mode, nb.selection.value = selectorMode(mode, value)
+	return nb
+}
+
+// Delay delays the packets by the given latency
+func (nb *NetworkChaosBuilder) Delay(latency time.Duration) *NetworkChaosBuilder {
+	nb.setAction(chaosmeshv1alpha1.DelayAction)
+	nb.obj.Spec.Delay = &chaosmeshv1alpha1.DelaySpec{Latency: latency.String()}
+	return nb
+}
+
+// Jitter varies the delay of the packets by up to the given duration, it requires a delay
+func (nb *NetworkChaosBuilder) Jitter(jitter time.Duration) *NetworkChaosBuilder {
+	nb.jitter = &jitter
+	return nb
+}
+
+// Loss drops the given percentage of the packets
+func (nb *NetworkChaosBuilder) Loss(percent float64) *NetworkChaosBuilder {
+	nb.setAction(chaosmeshv1alpha1.LossAction)
+	nb.obj.Spec.Loss = &chaosmeshv1alpha1.LossSpec{Loss: formatPercent(percent)}
+	return nb
+}
+
+// Duplicate duplicates the given percentage of the packets
+func (nb *NetworkChaosBuilder) Duplicate(percent float64) *NetworkChaosBuilder {
+	nb.setAction(chaosmeshv1alpha1.DuplicateAction)
+	nb.obj.Spec.Duplicate = &chaosmeshv1alpha1.DuplicateSpec{Duplicate: formatPercent(percent)}
+	return nb
+}
+
+// Corrupt corrupts the given percentage of the packets
+func (nb *NetworkChaosBuilder) Corrupt(percent float64) *NetworkChaosBuilder {
+	nb.setAction(chaosmeshv1alpha1.CorruptAction)
+	nb.obj.Spec.Corrupt = &chaosmeshv1alpha1.CorruptSpec{Corrupt: formatPercent(percent)}
+	return nb
+}
+
+// Correlation sets how much the fault of a packet depends on the previous one, in percent,
+// it requires a delay, loss, duplicate or corrupt fault
+func (nb *NetworkChaosBuilder) Correlation(percent float64) *NetworkChaosBuilder {
+	nb.correlation = &percent
+	return nb
+}
+
+// Bandwidth limits the bandwidth to the given rate, such as "1mbps"
+func (nb *NetworkChaosBuilder) Bandwidth(rate string, limit, buffer uint32) *NetworkChaosBuilder {
+	nb.setAction(chaosmeshv1alpha1.BandwidthAction)
+	nb.obj.Spec.Bandwidth = &chaosmeshv1alpha1.BandwidthSpec{Rate: rate, Limit: limit, Buffer: buffer}
+	return nb
+}
+
+// Partition cuts the network between the selected pods and the target
+func (nb *NetworkChaosBuilder) Partition() *NetworkChaosBuilder {
+	nb.setAction(chaosmeshv1alpha1.PartitionAction)
+	return nb
+}
+
+// Direction sets which packets between the selected pods and the target are affected
+func (nb *NetworkChaosBuilder) Direction(direction chaosmeshv1alpha1.Direction) *NetworkChaosBuilder {
+	nb.obj.Spec.Direction = direction
+	return nb
+}
+
+// Targeting limits the fault to the traffic with the pods of the given namespace and labels
+func (nb *NetworkChaosBuilder) Targeting(namespace string, labels map[string]string) *NetworkChaosBuilder {
+	nb.target = &podSelection{namespaces: []string{namespace}}
+	nb.target.withLabels(labels)
+	return nb
+}
+
+// ExternalTargets limits the fault to the traffic with the given hosts or IP blocks outside the cluster
+func (nb *NetworkChaosBuilder) ExternalTargets(targets ...string) *NetworkChaosBuilder {
+	nb.obj.Spec.ExternalTargets = append(nb.obj.Spec.ExternalTargets, targets...)
+	return nb
+}
+
+// Duration recovers the fault after the given duration, otherwise it lasts until the experiment is removed
+func (nb *NetworkChaosBuilder) Duration(d time.Duration) *NetworkChaosBuilder {
+	nb.obj.Spec.Duration = durationPtr(d)
+	return nb
+}
+
+func (nb *NetworkChaosBuilder) setAction(action chaosmeshv1alpha1.NetworkChaosAction) {
+	if nb.obj.Spec.Action != "" && nb.obj.Spec.Action != action {
+		nb.errs = append(nb.errs, errors.Errorf("%s cannot be combined with %s", action, nb.obj.Spec.Action))
+	}
+	nb.obj.Spec.Action = action
+}
+
+func (nb *NetworkChaosBuilder) build() (interface{}, error) {
+	errs := append([]error(nil), nb.errs...)
+	if nb.obj.Spec.Action == "" {
+		errs = append(errs, errors.New("no network fault was chosen"))
+	}
+
+	obj := nb.obj.DeepCopy()
+	if nb.jitter != nil {
+		if obj.Spec.Delay == nil {
+			errs = append(errs, errors.New("jitter requires a delay"))
+		} else {
+			obj.Spec.Delay.Jitter = nb.jitter.String()
+		}
+	}
+	if nb.correlation != nil {
+		err := setCorrelation(&obj.Spec, formatPercent(*nb.correlation))
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, errors.Wrapf(err, "invalid network chaos %s", nb.obj.Name)
+	}
+
+	obj.TypeMeta = chaosTypeMeta(chaosmeshv1alpha1.KindNetworkChaos)
+	obj.Spec.PodSelector = nb.selection.podSelector(obj.Namespace)
+	if nb.target != nil {
+		target := nb.target.podSelector(obj.Namespace)
+		obj.Spec.Target = &target
+	}
+	return obj, nil
+}
+
+// setCorrelation sets the correlation of the fault of the spec
+func setCorrelation(spec *chaosmeshv1alpha1.NetworkChaosSpec, correlation string) error {
+	switch spec.Action {
+	case chaosmeshv1alpha1.DelayAction:
+		spec.Delay.Correlation = correlation
+	case chaosmeshv1alpha1.LossAction:
+		spec.Loss.Correlation = correlation
+	case chaosmeshv1alpha1.DuplicateAction:
+		spec.Duplicate.Correlation = correlation
+	case chaosmeshv1alpha1.CorruptAction:
+		spec.Corrupt.Correlation = correlation
+	default:
+		return errors.New("correlation requires a delay, loss, duplicate or corrupt fault")
+	}
+	return nil
+}
+
+// PodChaosBuilder builds a PodChaos, the action is set by the fault it injects
+type PodChaosBuilder struct {
+	obj       chaosmeshv1alpha1.PodChaos
+	selection podSelection
+}
+
+// InNamespace sets the namespace of the experiment, its pods are selected unless other namespaces are
+func (pb *PodChaosBuilder) InNamespace(namespace string) *PodChaosBuilder {
+	pb.obj.Namespace = namespace
+	return pb
+}
+
+// InNamespaces selects the pods of the given namespaces
+func (pb *PodChaosBuilder) InNamespaces(namespaces ...string) *PodChaosBuilder {
+	pb.selection.namespaces = namespaces
+	return pb
+}
+
+// WithLabels selects the pods with the given labels
+func (pb *PodChaosBuilder) WithLabels(labels map[string]string) *PodChaosBuilder {
+	pb.selection.withLabels(labels)
+	return pb
+}
+
+// Mode sets how many of the selected pods are injected, all of them by default
+func (pb *PodChaosBuilder) Mode(mode chaosmeshv1alpha1.SelectorMode, value ...string) *PodChaosBuilder {
+	pb.selection.mode, pb.selection.value = selectorMode(mode, value)
+	return pb
+}
+
+// Kill kills the selected pods
+func (pb *PodChaosBuilder) Kill() *PodChaosBuilder {
+	pb.obj.Spec.Action = chaosmeshv1alpha1.PodKillAction
+	return pb
+}
+
+// Failure makes the selected pods unavailable
+func (pb *PodChaosBuilder) Failure() *PodChaosBuilder {
+	pb.obj.Spec.Action = chaosmeshv1alpha1.PodFailureAction
+	return pb
+}
+
+// KillContainers kills the given containers of the selected pods
+func (pb *PodChaosBuilder) KillContainers(containers ...string) *PodChaosBuilder {
+	pb.obj.Spec.Action = chaosmeshv1alpha1.ContainerKillAction
+	pb.obj.Spec.ContainerNames = containers
+	return pb
+}
+
+// GracePeriod sets how long killed pods are given to terminate
+func (pb *PodChaosBuilder) GracePeriod(d time.Duration) *PodChaosBuilder {
+	pb.obj.Spec.GracePeriod = int64(d / time.Second)
+	return pb
+}
+
+// Duration recovers the fault after the given duration, otherwise it lasts until the experiment is removed
+func (pb *PodChaosBuilder) Duration(d time.Duration) *PodChaosBuilder {
+	pb.obj.Spec.Duration = durationPtr(d)
+	return pb
+}
+
+func (pb *PodChaosBuilder) build() (interface{}, error) {
+	if pb.obj.Spec.Action == "" {
+		return nil, errors.Errorf("invalid pod chaos %s: no pod fault was chosen", pb.obj.Name)
+	}
+
+	obj := pb.obj.DeepCopy()
+	obj.TypeMeta = chaosTypeMeta(chaosmeshv1alpha1.KindPodChaos)
+	obj.Spec.PodSelector = pb.selection.podSelector(obj.Namespace)
+	return obj, nil
+}
+
+// StressChaosBuilder builds a StressChaos from the stressors it runs
+type StressChaosBuilder struct {
+	obj       chaosmeshv1alpha1.StressChaos
+	selection podSelection
+}
+
+// InNamespace sets the namespace of the experiment, its pods are selected unless other namespaces are
+func (sb *StressChaosBuilder) InNamespace(namespace string) *StressChaosBuilder {
+	sb.obj.Namespace = namespace
+	return sb
+}
+
+// InNamespaces selects the pods of the given namespaces
+func (sb *StressChaosBuilder) InNamespaces(namespaces ...string) *StressChaosBuilder {
+	sb.selection.namespaces = namespaces
+	return sb
+}
+
+// WithLabels selects the pods with the given labels
+func (sb *StressChaosBuilder) WithLabels(labels map[string]string) *StressChaosBuilder {
+	sb.selection.withLabels(labels)
+	return sb
+}
+
+// Mode sets how many of the selected pods are injected, all of them by default
+func (sb *StressChaosBuilder) Mode(mode chaosmeshv1alpha1.SelectorMode, value ...string) *StressChaosBuilder {
+	sb.selection.mode, sb.selection.value = selectorMode(mode, value)
+	return sb
+}
+
+// Containers stresses the given containers of the selected pods, instead of their first container
+func (sb *StressChaosBuilder) Containers(containers ...string) *StressChaosBuilder {
+	sb.obj.Spec.ContainerNames = containers
+	return sb
+}
+
+// CPU runs the given number of workers, each one loading a CPU by the given percentage
+func (sb *StressChaosBuilder) CPU(workers int, load int) *StressChaosBuilder {
+	sb.stressors().CPUStressor = &chaosmeshv1alpha1.CPUStressor{
+		Stressor: chaosmeshv1alpha1.Stressor{Workers: workers},
+		Load:     &load,
+	}
+	return sb
+}
+
+// Memory runs the given number of workers, each one allocating the given size, such as "256MB" or "50%"
+func (sb *StressChaosBuilder) Memory(workers int, size string) *StressChaosBuilder {
+	sb.stressors().MemoryStressor = &chaosmeshv1alpha1.MemoryStressor{
+		Stressor: chaosmeshv1alpha1.Stressor{Workers: workers},
+		Size:     size,
+	}
+	return sb
+}
+
+// Duration recovers the fault after the given duration, otherwise it lasts until the experiment is removed
+func (sb *StressChaosBuilder) Duration(d time.Duration) *StressChaosBuilder {
+	sb.obj.Spec.Duration = durationPtr(d)
+	return sb
+}
+
+func (sb *StressChaosBuilder) stressors() *chaosmeshv1alpha1.Stressors {
+	if sb.obj.Spec.Stressors == nil {
+		sb.obj.Spec.Stressors = &chaosmeshv1alpha1.Stressors{}
+	}
+	return sb.obj.Spec.Stressors
+}
+
+func (sb *StressChaosBuilder) build() (interface{}, error) {
+	if sb.obj.Spec.Stressors == nil {
+		return nil, errors.Errorf("invalid stress chaos %s: no stressor was chosen", sb.obj.Name)
+	}
+
+	obj := sb.obj.DeepCopy()
+	obj.TypeMeta = chaosTypeMeta(chaosmeshv1alpha1.KindStressChaos)
+	obj.Spec.PodSelector = sb.selection.podSelector(obj.Namespace)
+	return obj, nil
+}
+
+func selectorMode(mode chaosmeshv1alpha1.SelectorMode, value []string) (chaosmeshv1alpha1.SelectorMode, string) {
+	if len(value) == 0 {
+		return mode, ""
+	}
+	return mode, value[0]
+}
+
+func chaosTypeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{APIVersion: chaosmeshv1alpha1.GroupVersion.String(), Kind: kind}
+}
+
+func formatPercent(percent float64) string {
+	return strconv.FormatFloat(percent, 'f', -1, 64)
+}
+
+func durationPtr(d time.Duration) *string {
+	s := d.String()
+	return &s
+}
@@ -0,0 +1,105 @@
+package chaosmesh
+
+import (
+	"testing"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNetworkChaosBuilder(t *testing.T) {
+	duration := "5m0s"
+	expected := &chaosmeshv1alpha1.NetworkChaos{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "chaos-mesh.org/v1alpha1", Kind: "NetworkChaos"},
+		ObjectMeta: metav1.ObjectMeta{Name: "api-delay", Namespace: "payments"},
+		Spec: chaosmeshv1alpha1.NetworkChaosSpec{
+			PodSelector: chaosmeshv1alpha1.PodSelector{
+				Mode: chaosmeshv1alpha1.AllMode,
+				Selector: chaosmeshv1alpha1.PodSelectorSpec{
+					GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
+						Namespaces:     []string{"payments"},
+						LabelSelectors: map[string]string{"app": "api"},
+					},
+				},
+			},
+			Action: chaosmeshv1alpha1.DelayAction,
+			TcParameter: chaosmeshv1alpha1.TcParameter{
+				Delay: &chaosmeshv1alpha1.DelaySpec{Latency: "100ms", Jitter: "10ms", Correlation: "25.5"},
+			},
+			Direction: chaosmeshv1alpha1.To,
+			Target: &chaosmeshv1alpha1.PodSelector{
+				Mode: chaosmeshv1alpha1.AllMode,
+				Selector: chaosmeshv1alpha1.PodSelectorSpec{
+					GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
+						Namespaces:     []string{"payments"},
+						LabelSelectors: map[string]string{"app": "db"},
+					},
+				},
+			},
+			Duration: &duration,
+		},
+	}
+
+	tests := []struct {
+		name  string
+		build func(nb *NetworkChaosBuilder)
+	}{
+		{name: "fault refined after it is chosen", build: func(nb *NetworkChaosBuilder) {
+			nb.Delay(100 * time.Millisecond).Jitter(10 * time.Millisecond).Correlation(25.5)
+		}},
+		{name: "fault refined before it is chosen", build: func(nb *NetworkChaosBuilder) {
+			nb.Correlation(25.5).Jitter(10 * time.Millisecond).Delay(100 * time.Millisecond)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nb := NewChaosExperimentsBuilder().Network("api-delay").
+				InNamespace("payments").
+				WithLabels(map[string]string{"app": "api"}).
+				Direction(To).
+				Targeting("payments", map[string]string{"app": "db"}).
+				Duration(5 * time.Minute)
+			tt.build(nb)
+
+			obj, err := nb.build()
+
+			require.NoError(t, err)
+			require.Equal(t, expected, obj)
+		})
+	}
+}
+
+func TestNetworkChaosBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(nb *NetworkChaosBuilder)
+		err   string
+	}{
+		{name: "no fault", build: func(nb *NetworkChaosBuilder) {
+			nb.Direction(To)
+		}, err: "invalid network chaos broken: no network fault was chosen"},
+		{name: "jitter without a delay", build: func(nb *NetworkChaosBuilder) {
+			nb.Jitter(time.Millisecond).Loss(10)
+		}, err: "invalid network chaos broken: jitter requires a delay"},
+		{name: "correlation of a partition", build: func(nb *NetworkChaosBuilder) {
+			nb.Correlation(10).Partition()
+		}, err: "invalid network chaos broken: correlation requires a delay, loss, duplicate or corrupt fault"},
+		{name: "combined faults", build: func(nb *NetworkChaosBuilder) {
+			nb.Delay(time.Millisecond).Loss(10)
+		}, err: "invalid network chaos broken: loss cannot be combined with delay"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nb := NewChaosExperimentsBuilder().Network("broken")
+			tt.build(nb)
+
+			_, err := nb.build()
+
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
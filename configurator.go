@@ -3,21 +3,19 @@ package chaosmesh
 import (
 	"context"
 	"fmt"
-	"os"
-	"strings"
-	"time"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/form3tech-oss/f1/pkg/f1/testing"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
-	yamlUtil "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -25,146 +23,256 @@ type experimentsConfigurator struct {
 	experiments *chaosExperiments
 	kubeCli     client.Client
 	t           *testing.T
+	run         *runMetadata
+	opts        *options
+
+	mu      sync.Mutex
+	created []*createdExperiment
+
+	// delayed experiments are created by the timeline once the load test has started
+	delayed      []*plannedExperiment
+	stopTimeline context.CancelFunc
+	timelineWg   sync.WaitGroup
+	runErrs      []error
+}
+
+// plannedExperiment is an experiment object yet to be created, along with the options of its experiment
+type plannedExperiment struct {
+	gvk  schema.GroupVersionKind
+	obj  client.Object
+	opts *experimentOptions
+}
+
+// createdExperiment is an experiment that was actually created in the cluster,
+// its object holds the UID assigned by the server
+type createdExperiment struct {
+	gvk  schema.GroupVersionKind
+	obj  client.Object
+	opts *experimentOptions
 }
 
-func newExperimentsConfigurator(t *testing.T, kubeCli client.Client, experiments *chaosExperiments) *experimentsConfigurator {
+func newExperimentsConfigurator(t *testing.T, kubeCli client.Client, experiments *chaosExperiments, run *runMetadata, opts *options) *experimentsConfigurator {
 	return &experimentsConfigurator{
 		experiments: experiments,
 		kubeCli:     kubeCli,
 		t:           t,
+		run:         run,
+		opts:        opts,
 	}
 }
 
+// ConfigureExperiments creates the experiments and then waits for all of them to be injected at once,
+// if any of them fails the experiments created so far are rolled back
 func (c *experimentsConfigurator) ConfigureExperiments() error {
 	c.t.Logger.Info("Setting up chaos experiments")
 
-	for gvk, cc := range c.experiments.chaos {
-		for _, ccc := range cc {
-			err := c.createChaos(gvk, ccc)
-			if err != nil {
-				c.t.Error(err)
-				return err
-			}
-		}
+	planned, delayed := splitDelayed(c.plannedExperiments())
+	c.delayed = delayed
+	if c.opts.releaseAllAtOnce {
+		return c.configureExperimentsAllAtOnce(planned)
 	}
 
-	for gvk, exps := range c.experiments.chaosFromFiles {
-		for _, filePath := range exps {
-			err := c.createChaosFromFile(gvk, filePath)
-			if err != nil {
-				return err
-			}
-		}
+	err := c.createExperiments(planned)
+	if err != nil {
+		return c.rollback(err)
 	}
 
-	for gvk, exps := range c.experiments.chaosFromYaml {
-		for _, yaml := range exps {
-			err := c.createChaosFromYaml(gvk, yaml)
-			if err != nil {
-				return err
-			}
-		}
+	err = c.waitForExperimentsToBeReady(c.createdExperiments())
+	if err != nil {
+		return c.rollback(err)
 	}
 
-	for _, wf := range c.experiments.chaosWorkflows {
-		err := c.createChaosWorkflow(wf)
-		if err != nil {
-			return err
-		}
-	}
+	c.t.Logger.Info("Chaos experiments are ready")
+	return nil
+}
 
-	for _, filePath := range c.experiments.chaosWorkflowsFromFiles {
-		err := c.createChaosWorkflowFromFile(filePath)
-		if err != nil {
-			return err
+func (c *experimentsConfigurator) plannedExperiments() []*plannedExperiment {
+	var planned []*plannedExperiment
+	for _, exp := range c.experiments.experiments {
+		for _, o := range exp.objs {
+			planned = append(planned, &plannedExperiment{gvk: o.gvk, obj: o.obj, opts: exp.opts})
 		}
 	}
+	return planned
+}
 
-	for _, yaml := range c.experiments.chaosWorkflowsFromYaml {
-		err := c.createChaosWorkflowFromYaml(yaml)
-		if err != nil {
-			return err
+// createExperiments sends up to the configured number of create requests at a time,
+// with a concurrency of one the experiments are created strictly in order.
+// No further experiment is created once one of them has failed.
+func (c *experimentsConfigurator) createExperiments(planned []*plannedExperiment) error {
+	sem := make(chan struct{}, c.opts.concurrency)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, p := range planned {
+		sem <- struct{}{}
+		mu.Lock()
+		failed := len(errs) > 0
+		mu.Unlock()
+		if failed {
+			<-sem
+			break
 		}
+
+		wg.Add(1)
+		go func(p *plannedExperiment) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			_, err := c.createExperiment(p.gvk, p.obj, p.opts)
+			if err != nil {
+				expFriendlyName := generateExperimentFriendlyName(p.gvk.Kind, p.obj.GetNamespace(), p.obj.GetName())
+				mu.Lock()
+				errs = append(errs, errors.Wrapf(err, "chaos experiment %s failed", expFriendlyName))
+				mu.Unlock()
+			}
+		}(p)
 	}
+	wg.Wait()
 
-	return nil
+	return utilerrors.NewAggregate(errs)
 }
 
+// CleanupExperiments deletes the experiments created by ConfigureExperiments in reverse order.
+// Every experiment is deleted at most once, so it is safe to call it more than once.
 func (c *experimentsConfigurator) CleanupExperiments() error {
-	c.t.Logger.Info("Cleaning up chaos experiments")
+	return c.cleanupExperiments(true)
+}
 
-	for gvk, cc := range c.experiments.chaos {
-		for _, ccc := range cc {
-			err := c.deleteChaos(gvk, ccc)
-			if err != nil {
-				c.t.Logger.Error(err)
-			}
-		}
-	}
+// cleanupExperiments deletes the created experiments, a rollback does not wait for workflows to complete
+func (c *experimentsConfigurator) cleanupExperiments(waitForWorkflows bool) error {
+	c.mu.Lock()
+	created := c.created
+	c.created = nil
+	c.mu.Unlock()
 
-	for gvk, cc := range c.experiments.chaosFromFiles {
-		for _, ccc := range cc {
-			err := c.deleteChaosFromFile(gvk, ccc)
-			if err != nil {
-				c.t.Logger.Error(err)
-			}
-		}
+	if len(created) == 0 {
+		return nil
 	}
 
-	for gvk, cc := range c.experiments.chaosFromYaml {
-		for _, ccc := range cc {
-			err := c.deleteChaosFromYaml(gvk, ccc)
+	c.t.Logger.Info("Cleaning up chaos experiments")
+
+	var errs []error
+	for i := len(created) - 1; i >= 0; i-- {
+		exp := created[i]
+		if wf, ok := exp.obj.(*chaosmeshv1alpha1.Workflow); ok && waitForWorkflows && exp.opts.waitForWorkflowCompletion {
+			err := c.waitForWorkflowCompletion(wf, exp.opts)
 			if err != nil {
-				c.t.Logger.Error(err)
+				expFriendlyName := generateExperimentFriendlyName(exp.gvk.Kind, exp.obj.GetNamespace(), exp.obj.GetName())
+				errs = append(errs, errors.Wrapf(err, "chaos workflow %s", expFriendlyName))
 			}
 		}
-	}
-
-	for _, wf := range c.experiments.chaosWorkflows {
-		err := c.deleteChaosWorkflow(wf)
+		err := c.deleteExperiment(exp.gvk, exp.obj, exp.opts)
 		if err != nil {
-			c.t.Logger.Error(err)
+			expFriendlyName := generateExperimentFriendlyName(exp.gvk.Kind, exp.obj.GetNamespace(), exp.obj.GetName())
+			errs = append(errs, errors.Wrapf(err, "could not clean up chaos experiment %s", expFriendlyName))
+			continue
 		}
+		liveExperiments.remove(exp.obj)
 	}
 
-	for _, wf := range c.experiments.chaosWorkflowsFromFiles {
-		err := c.deleteChaosWorkflowFromFile(wf)
-		if err != nil {
-			c.t.Logger.Error(err)
-		}
-	}
+	return utilerrors.NewAggregate(errs)
+}
 
-	for _, wf := range c.experiments.chaosWorkflowsFromYaml {
-		err := c.deleteChaosWorkflowFromYaml(wf)
-		if err != nil {
-			c.t.Logger.Error(err)
+func (c *experimentsConfigurator) rollback(cause error) error {
+	c.t.Logger.Errorf("Rolling back chaos experiments, err: %s", cause)
+	err := c.cleanupExperiments(false)
+	return utilerrors.Flatten(utilerrors.NewAggregate([]error{cause, err}))
+}
+
+func (c *experimentsConfigurator) trackCreated(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions) {
+	c.mu.Lock()
+	c.created = append(c.created, &createdExperiment{gvk: gvk, obj: obj, opts: opts})
+	c.mu.Unlock()
+	liveExperiments.add(c.kubeCli, gvk, obj, c.opts.interruptCleanupTimeout)
+}
+
+// untrackCreated forgets an experiment deleted before the cleanup
+func (c *experimentsConfigurator) untrackCreated(obj client.Object) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, exp := range c.created {
+		if exp.obj.GetUID() == obj.GetUID() {
+			c.created = append(c.created[:i], c.created[i+1:]...)
+			break
 		}
 	}
+	liveExperiments.remove(obj)
+}
 
-	return nil
+// createdExperiments returns the experiments created so far, in the order they were created
+func (c *experimentsConfigurator) createdExperiments() []*createdExperiment {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*createdExperiment(nil), c.created...)
 }
 
-func (c *experimentsConfigurator) createChaos(gvk schema.GroupVersionKind, obj *unstructured.Unstructured) error {
-	expFriendlyName := generateExperimentFriendlyName(gvk.Kind, obj.GetNamespace(), obj.GetName())
+// createExperiment creates a named copy of the experiment and returns it, it is the object tracked for the cleanup
+func (c *experimentsConfigurator) createExperiment(gvk schema.GroupVersionKind, defined client.Object, opts *experimentOptions) (client.Object, error) {
+	obj := c.nameExperiment(defined)
+	c.run.stamp(obj, c.t.Scenario)
 
-	c.t.Logger.Infof("Setting up chaos experiment %s", expFriendlyName)
-	err := c.kubeCli.Create(context.Background(), obj, &client.CreateOptions{})
-	if err != nil {
-		c.t.Logger.Errorf("Error setting up chaos experiment %s", expFriendlyName)
-		return err
+	err := c.createByKind(gvk, obj, opts)
+	if apierrors.IsAlreadyExists(err) {
+		err = c.resolveConflict(gvk, obj, opts, err)
 	}
 
-	err = c.waitForExperimentToBeInjected(gvk, obj, expFriendlyName)
-	if err != nil {
-		c.t.Logger.Errorf("Chaos experiment %s was not injected, err: %s", expFriendlyName, err)
+	// the experiment is tracked, and cleaned up, by the name the server created it with
+	if err == nil && obj.GetName() != definedName(obj) {
+		c.t.Logger.Infof("Chaos experiment %s was created as %s", definedName(obj),
+			generateExperimentFriendlyName(gvk.Kind, obj.GetNamespace(), obj.GetName()))
+	}
+	return obj, err
+}
+
+func (c *experimentsConfigurator) createByKind(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions) error {
+	switch o := obj.(type) {
+	case *chaosmeshv1alpha1.Workflow:
+		return c.createChaosWorkflow(o, opts)
+	case *chaosmeshv1alpha1.Schedule:
+		return c.createChaosSchedule(o, opts)
+	case *unstructured.Unstructured:
+		return c.createChaos(gvk, o, opts)
+	default:
+		return fmt.Errorf("unsupported experiment type %T", obj)
+	}
+}
+
+func (c *experimentsConfigurator) deleteExperiment(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions) error {
+	err := c.deleteByKind(gvk, obj, opts)
+	if err != nil || !opts.waitForRecovery {
 		return err
 	}
 
-	return nil
+	return c.waitForExperimentToBeRecovered(gvk, obj, opts)
+}
+
+func (c *experimentsConfigurator) deleteByKind(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions) error {
+	switch o := obj.(type) {
+	case *chaosmeshv1alpha1.Workflow:
+		return c.deleteChaosWorkflow(o, opts)
+	case *chaosmeshv1alpha1.Schedule:
+		return c.deleteChaosSchedule(o, opts)
+	case *unstructured.Unstructured:
+		return c.deleteChaos(gvk, o, opts)
+	default:
+		return fmt.Errorf("unsupported experiment type %T", obj)
+	}
 }
 
-func (c *experimentsConfigurator) waitForExperimentToBeInjected(gvk schema.GroupVersionKind, obj *unstructured.Unstructured, expFriendlyName string) error {
-	return wait.PollImmediate(2*time.Second, 1*time.Minute, func() (bool, error) {
+// waitForExperimentToBeRecovered waits until the experiment is gone from the cluster,
+// chaos mesh only removes its finalizers once every target has been recovered
+func (c *experimentsConfigurator) waitForExperimentToBeRecovered(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions) error {
+	expFriendlyName := generateExperimentFriendlyName(gvk.Kind, obj.GetNamespace(), obj.GetName())
+	c.t.Logger.Infof("Waiting for chaos experiment %s to be recovered", expFriendlyName)
+
+	pending := "not yet deleted"
+	err := wait.PollImmediate(opts.pollInterval, opts.cleanupTimeout, func() (bool, error) {
 		updObj := &unstructured.Unstructured{}
 		updObj.SetGroupVersionKind(gvk)
 
@@ -173,199 +281,192 @@ func (c *experimentsConfigurator) waitForExperimentToBeInjected(gvk schema.Group
 			types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()},
 			updObj)
 
-		if err != nil {
-			c.t.Logger.Infof("Could not get chaos experiment %s, err: %s", expFriendlyName, err)
-			return false, nil
+		if apierrors.IsNotFound(err) {
+			if gvk.Kind == "Schedule" {
+				return c.scheduleChildrenRemoved(obj.(*chaosmeshv1alpha1.Schedule), &pending)
+			}
+			return true, nil
 		}
-
-		var status chaosmeshv1alpha1.ChaosStatus
-		unstructuredStatus := updObj.Object["status"].(map[string]interface{})
-		err = runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredStatus, &status)
 		if err != nil {
-			c.t.Logger.Infof("Could not get chaos experiment status %s, err: %s", expFriendlyName, err)
+			c.t.Logger.Infof("Could not get chaos experiment %s, err: %s", expFriendlyName, err)
 			return false, nil
 		}
 
-		return experimentHasCondition(status, chaosmeshv1alpha1.ConditionAllInjected), nil
+		pending = describePendingRecovery(updObj)
+		c.t.Logger.Infof("Chaos experiment %s is %s", expFriendlyName, pending)
+		return false, nil
 	})
-}
 
-func (c *experimentsConfigurator) createChaosFromFile(gvk schema.GroupVersionKind, filePath string) error {
-	obj := &unstructured.Unstructured{}
-	obj.SetGroupVersionKind(gvk)
-	err := unmarshalFile(filePath, obj)
 	if err != nil {
-		return err
+		c.t.Logger.Errorf("Chaos experiment %s was not recovered, err: %s", expFriendlyName, err)
+		return errors.Wrapf(err, "not recovered after %s, %s", opts.cleanupTimeout, pending)
 	}
 
-	return c.createChaos(gvk, obj)
+	return nil
 }
 
-func (c *experimentsConfigurator) createChaosFromYaml(gvk schema.GroupVersionKind, yaml string) error {
-	obj := &unstructured.Unstructured{}
-	obj.SetGroupVersionKind(gvk)
-	err := unmarshalYaml(yaml, obj)
+func (c *experimentsConfigurator) scheduleChildrenRemoved(sc *chaosmeshv1alpha1.Schedule, pending *string) (bool, error) {
+	children := &unstructured.UnstructuredList{}
+	children.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind(string(sc.Spec.Type) + "List"))
+	err := c.kubeCli.List(
+		context.Background(),
+		children,
+		client.InNamespace(sc.GetNamespace()),
+		client.MatchingLabels{chaosmeshv1alpha1.LabelManagedBy: sc.GetName()})
 	if err != nil {
-		return err
+		c.t.Logger.Infof("Could not list the experiments spawned by chaos schedule %s, err: %s",
+			generateExperimentFriendlyName("Schedule", sc.GetNamespace(), sc.GetName()), err)
+		*pending = fmt.Sprintf("could not list spawned experiments: %s", err)
+		return false, nil
 	}
 
-	return c.createChaos(gvk, obj)
+	*pending = fmt.Sprintf("waiting for %d spawned experiments to be removed", len(children.Items))
+	return len(children.Items) == 0, nil
 }
 
-func (c *experimentsConfigurator) deleteChaos(gvk schema.GroupVersionKind, obj *unstructured.Unstructured) error {
-	expFriendlyName := generateExperimentFriendlyName(gvk.Kind, obj.GetNamespace(), obj.GetName())
-	c.t.Logger.Infof("Cleaning up chaos experiment %s", expFriendlyName)
-	err := c.kubeCli.Delete(context.Background(), obj, &client.DeleteOptions{})
-	if err != nil {
-		c.t.Logger.Errorf("Error cleaning up chaos experiment %s", expFriendlyName)
-		return err
+// describePendingRecovery reports why an experiment being deleted is still present
+func describePendingRecovery(obj *unstructured.Unstructured) string {
+	status, reconciled := chaosStatusOf(obj)
+	if !reconciled {
+		return fmt.Sprintf("pending finalizers %v", obj.GetFinalizers())
 	}
-	return nil
+
+	recovered := 0
+	for _, r := range status.Experiment.Records {
+		if r.Phase == chaosmeshv1alpha1.NotInjected {
+			recovered++
+		}
+	}
+
+	return fmt.Sprintf("recovering, %d/%d records recovered, pending finalizers %v",
+		recovered, len(status.Experiment.Records), obj.GetFinalizers())
 }
 
-func (c *experimentsConfigurator) deleteChaosFromFile(gvk schema.GroupVersionKind, filePath string) error {
-	obj := &unstructured.Unstructured{}
-	obj.SetGroupVersionKind(gvk)
-	err := unmarshalFile(filePath, obj)
+func (c *experimentsConfigurator) createChaos(gvk schema.GroupVersionKind, obj *unstructured.Unstructured, opts *experimentOptions) error {
+	expFriendlyName := generateExperimentFriendlyName(gvk.Kind, obj.GetNamespace(), obj.GetName())
+
+	c.t.Logger.Infof("Setting up chaos experiment %s", expFriendlyName)
+	err := c.kubeCli.Create(context.Background(), obj, &client.CreateOptions{})
 	if err != nil {
+		// an experiment that already exists is handled by the conflict policy
+		if !apierrors.IsAlreadyExists(err) {
+			c.t.Logger.Errorf("Error setting up chaos experiment %s", expFriendlyName)
+		}
 		return err
 	}
+	c.trackCreated(gvk, obj, opts)
 
-	return c.deleteChaos(gvk, obj)
+	return nil
 }
 
-func (c *experimentsConfigurator) deleteChaosFromYaml(gvk schema.GroupVersionKind, yaml string) error {
-	obj := &unstructured.Unstructured{}
-	obj.SetGroupVersionKind(gvk)
-	err := unmarshalYaml(yaml, obj)
+func (c *experimentsConfigurator) deleteChaos(gvk schema.GroupVersionKind, obj *unstructured.Unstructured, opts *experimentOptions) error {
+	expFriendlyName := generateExperimentFriendlyName(gvk.Kind, obj.GetNamespace(), obj.GetName())
+	c.t.Logger.Infof("Cleaning up chaos experiment %s (cleanup timeout: %s)", expFriendlyName, opts.cleanupTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.cleanupTimeout)
+	defer cancel()
+
+	// the experiment may have already been deleted when the process was interrupted
+	err := client.IgnoreNotFound(c.kubeCli.Delete(ctx, obj, uidPrecondition(obj)))
 	if err != nil {
+		c.t.Logger.Errorf("Error cleaning up chaos experiment %s", expFriendlyName)
 		return err
 	}
-
-	return c.deleteChaos(gvk, obj)
+	return nil
 }
 
-func (c *experimentsConfigurator) createChaosWorkflow(wf *chaosmeshv1alpha1.Workflow) error {
+func (c *experimentsConfigurator) createChaosWorkflow(wf *chaosmeshv1alpha1.Workflow, opts *experimentOptions) error {
 	expFriendlyName := generateExperimentFriendlyName("Workflow", wf.GetNamespace(), wf.GetName())
 
 	c.t.Logger.Infof("Setting up chaos workflow %s", expFriendlyName)
 	err := c.kubeCli.Create(context.Background(), wf, &client.CreateOptions{})
 	if err != nil {
-		c.t.Logger.Errorf("Error setting up chaos workflow %s, err : %s", expFriendlyName, err)
-		return err
-	}
-
-	err = wait.PollImmediate(2*time.Second, 1*time.Minute, func() (bool, error) {
-		var updWf chaosmeshv1alpha1.Workflow
-		err := c.kubeCli.Get(
-			context.Background(),
-			types.NamespacedName{Namespace: wf.GetNamespace(), Name: wf.GetName()},
-			&updWf)
-
-		if err != nil {
-			c.t.Logger.Infof("Could not get chaos workflow %s, err: %s", expFriendlyName, err)
-			return false, nil
-		}
-
-		for _, sc := range updWf.Status.Conditions {
-			if sc.Type == chaosmeshv1alpha1.WorkflowConditionScheduled && sc.Status == corev1.ConditionTrue {
-				return true, nil
-			}
+		// an experiment that already exists is handled by the conflict policy
+		if !apierrors.IsAlreadyExists(err) {
+			c.t.Logger.Errorf("Error setting up chaos workflow %s, err : %s", expFriendlyName, err)
 		}
-		return false, nil
-	})
-
-	if err != nil {
-		c.t.Logger.Errorf("Chaos experiment %s was not injected, err: %s", expFriendlyName, err)
 		return err
 	}
+	c.trackCreated(chaosmeshv1alpha1.GroupVersion.WithKind("Workflow"), wf, opts)
 
 	return nil
 }
 
-func (c *experimentsConfigurator) createChaosWorkflowFromFile(filePath string) error {
-	wf := &chaosmeshv1alpha1.Workflow{}
-	err := unmarshalFile(filePath, wf)
-	if err != nil {
-		return err
-	}
+func (c *experimentsConfigurator) deleteChaosWorkflow(wf *chaosmeshv1alpha1.Workflow, opts *experimentOptions) error {
+	expFriendlyName := generateExperimentFriendlyName("Workflow", wf.GetNamespace(), wf.GetName())
 
-	return c.createChaosWorkflow(wf)
-}
+	c.t.Logger.Infof("Deleting chaos workflow %s (cleanup timeout: %s)", expFriendlyName, opts.cleanupTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.cleanupTimeout)
+	defer cancel()
 
-func (c *experimentsConfigurator) createChaosWorkflowFromYaml(yaml string) error {
-	wf := &chaosmeshv1alpha1.Workflow{}
-	err := unmarshalYaml(yaml, wf)
+	// the experiment may have already been deleted when the process was interrupted
+	err := client.IgnoreNotFound(c.kubeCli.Delete(ctx, wf, uidPrecondition(wf)))
 	if err != nil {
+		c.t.Logger.Errorf("Error deleting up chaos workflow %s", expFriendlyName)
 		return err
 	}
 
-	return c.createChaosWorkflow(wf)
+	return nil
 }
 
-func (c *experimentsConfigurator) deleteChaosWorkflow(wf *chaosmeshv1alpha1.Workflow) error {
-	expFriendlyName := generateExperimentFriendlyName("Workflow", wf.GetNamespace(), wf.GetName())
+func (c *experimentsConfigurator) createChaosSchedule(sc *chaosmeshv1alpha1.Schedule, opts *experimentOptions) error {
+	expFriendlyName := generateExperimentFriendlyName("Schedule", sc.GetNamespace(), sc.GetName())
 
-	c.t.Logger.Infof("Deleting chaos workflow %s", expFriendlyName)
-	err := c.kubeCli.Delete(context.Background(), wf, &client.DeleteOptions{})
+	c.t.Logger.Infof("Setting up chaos schedule %s", expFriendlyName)
+	err := c.kubeCli.Create(context.Background(), sc, &client.CreateOptions{})
 	if err != nil {
-		c.t.Logger.Errorf("Error deleting up chaos workflow %s", expFriendlyName)
+		// an experiment that already exists is handled by the conflict policy
+		if !apierrors.IsAlreadyExists(err) {
+			c.t.Logger.Errorf("Error setting up chaos schedule %s, err : %s", expFriendlyName, err)
+		}
 		return err
 	}
+	c.trackCreated(chaosmeshv1alpha1.GroupVersion.WithKind("Schedule"), sc, opts)
 
 	return nil
 }
 
-func (c *experimentsConfigurator) deleteChaosWorkflowFromFile(filePath string) error {
-	wf := &chaosmeshv1alpha1.Workflow{}
-	err := unmarshalFile(filePath, wf)
-	if err != nil {
-		return err
-	}
+func (c *experimentsConfigurator) deleteChaosSchedule(sc *chaosmeshv1alpha1.Schedule, opts *experimentOptions) error {
+	expFriendlyName := generateExperimentFriendlyName("Schedule", sc.GetNamespace(), sc.GetName())
 
-	return c.deleteChaosWorkflow(wf)
-}
+	c.t.Logger.Infof("Deleting chaos schedule %s (cleanup timeout: %s)", expFriendlyName, opts.cleanupTimeout)
 
-func (c *experimentsConfigurator) deleteChaosWorkflowFromYaml(yaml string) error {
-	wf := &chaosmeshv1alpha1.Workflow{}
-	err := unmarshalYaml(yaml, wf)
+	ctx, cancel := context.WithTimeout(context.Background(), opts.cleanupTimeout)
+	defer cancel()
+
+	// the experiment may have already been deleted when the process was interrupted
+	err := client.IgnoreNotFound(c.kubeCli.Delete(ctx, sc, uidPrecondition(sc)))
 	if err != nil {
+		c.t.Logger.Errorf("Error deleting chaos schedule %s", expFriendlyName)
 		return err
 	}
 
-	return c.deleteChaosWorkflow(wf)
-}
-
-func unmarshalFile(filePath string, obj interface{}) error {
-	f, err := os.Open(filePath)
-	if err != nil {
-		return errors.Wrapf(err, "error opening file %s", filePath)
-	}
-	err = yamlUtil.NewYAMLOrJSONDecoder(f, 100).Decode(obj)
+	err = deleteScheduleChildren(ctx, c.kubeCli, sc.GetNamespace(), sc.GetName(), string(sc.Spec.Type))
 	if err != nil {
-		return errors.Wrapf(err, "error decoding yaml from file %s", filePath)
+		c.t.Logger.Errorf("Error deleting experiments spawned by chaos schedule %s", expFriendlyName)
+		return err
 	}
 
 	return nil
 }
 
-func unmarshalYaml(yaml string, obj interface{}) error {
-	yr := strings.NewReader(yaml)
-	err := yamlUtil.NewYAMLOrJSONDecoder(yr, 100).Decode(obj)
-	if err != nil {
-		return err
-	}
-
-	return nil
+// deleteScheduleChildren deletes the experiments spawned by a schedule,
+// they are labeled with the name of the schedule that manages them
+func deleteScheduleChildren(ctx context.Context, kubeCli client.Client, namespace string, name string, kind string) error {
+	children := &unstructured.Unstructured{}
+	children.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind(kind))
+	return kubeCli.DeleteAllOf(
+		ctx,
+		children,
+		client.InNamespace(namespace),
+		client.MatchingLabels{chaosmeshv1alpha1.LabelManagedBy: name})
 }
 
-func experimentHasCondition(status chaosmeshv1alpha1.ChaosStatus, condition chaosmeshv1alpha1.ChaosConditionType) bool {
-	for _, sc := range status.Conditions {
-		if sc.Type == condition && sc.Status == corev1.ConditionTrue {
-			return true
-		}
-	}
-	return false
+// uidPrecondition makes sure only the object created by the configurator is deleted
+func uidPrecondition(obj client.Object) *client.DeleteOptions {
+	uid := obj.GetUID()
+	return &client.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}
 }
 
 func generateExperimentFriendlyName(experimentType string, namespace string, name string) string {
@@ -0,0 +1,164 @@
+package chaosmesh
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/samuel-form3/f1-chaos-mesh/validation"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConflictPolicy decides what happens when an experiment already exists in the cluster, left over from a crashed run for instance
+type ConflictPolicy string
+
+const (
+	// ConflictFail fails the scenario setup
+	ConflictFail ConflictPolicy = "fail"
+	// ConflictReplace deletes the existing experiment, waits for it to be removed and creates the experiment again
+	ConflictReplace ConflictPolicy = "replace"
+	// ConflictAdopt reuses the existing experiment when its spec is the same, and fails otherwise
+	ConflictAdopt ConflictPolicy = "adopt"
+	// ConflictRename creates the experiment with a random suffix added to its name
+	ConflictRename ConflictPolicy = "rename"
+)
+
+func (p ConflictPolicy) validate() error {
+	switch p {
+	case ConflictFail, ConflictReplace, ConflictAdopt, ConflictRename:
+		return nil
+	default:
+		return fmt.Errorf("unknown conflict policy %q", p)
+	}
+}
+
+// resolveConflict applies the conflict policy to an experiment that could not be created because it already exists
+func (c *experimentsConfigurator) resolveConflict(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions, cause error) error {
+	expFriendlyName := generateExperimentFriendlyName(gvk.Kind, obj.GetNamespace(), obj.GetName())
+
+	switch c.opts.conflictPolicy {
+	case ConflictReplace:
+		c.t.Logger.Warnf("Chaos experiment %s already exists, replacing it", expFriendlyName)
+		return c.replaceExperiment(gvk, obj, opts)
+	case ConflictAdopt:
+		c.t.Logger.Warnf("Chaos experiment %s already exists, adopting it", expFriendlyName)
+		return c.adoptExperiment(gvk, obj, opts)
+	case ConflictRename:
+		obj.SetName(fmt.Sprintf("%s-%s", obj.GetName(), rand.String(5)))
+		c.t.Logger.Warnf("Chaos experiment %s already exists, creating it as %s instead", expFriendlyName, obj.GetName())
+		return c.createByKind(gvk, obj, opts)
+	default:
+		c.t.Logger.Errorf("Chaos experiment %s already exists", expFriendlyName)
+		return cause
+	}
+}
+
+// replaceExperiment deletes the existing experiment, along with the experiments spawned by an existing schedule,
+// and creates the experiment once the existing one is gone
+func (c *experimentsConfigurator) replaceExperiment(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions) error {
+	existing, err := c.getExisting(gvk, obj, opts)
+	if err != nil {
+		return err
+	}
+
+	err = c.deleteByKind(gvk, existing, opts)
+	if err != nil {
+		return errors.Wrap(err, "could not delete the existing experiment")
+	}
+
+	err = c.waitForExperimentToBeRecovered(gvk, existing, opts)
+	if err != nil {
+		return errors.Wrap(err, "the existing experiment was not removed")
+	}
+
+	return c.createByKind(gvk, obj, opts)
+}
+
+// adoptExperiment tracks the existing experiment as if it had been created by this run, provided it has the same spec.
+// The specs are compared once the defaults of the chaos mesh webhooks are applied, as the server applied them to the existing one.
+func (c *experimentsConfigurator) adoptExperiment(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions) error {
+	existing, err := c.getExisting(gvk, obj, opts)
+	if err != nil {
+		return err
+	}
+
+	desiredSpec, err := defaultedSpec(gvk, obj)
+	if err != nil {
+		return errors.Wrap(err, "could not default the experiment")
+	}
+	existingSpec, err := defaultedSpec(gvk, existing)
+	if err != nil {
+		return errors.Wrap(err, "could not default the existing experiment")
+	}
+	if !equality.Semantic.DeepEqual(desiredSpec, existingSpec) {
+		return errors.New("spec conflict, the existing experiment has a different spec and cannot be adopted")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.injectionTimeout)
+	defer cancel()
+
+	// the experiment now belongs to this run, it is labeled as such so that it is not garbage collected
+	patch := client.MergeFrom(existing.DeepCopyObject().(client.Object))
+	existing.SetLabels(mergeStrings(existing.GetLabels(), obj.GetLabels()))
+	existing.SetAnnotations(mergeStrings(existing.GetAnnotations(), obj.GetAnnotations()))
+	err = c.kubeCli.Patch(ctx, existing, patch)
+	if err != nil {
+		return errors.Wrap(err, "could not label the existing experiment")
+	}
+
+	err = c.kubeCli.Get(ctx, client.ObjectKeyFromObject(existing), obj)
+	if err != nil {
+		return errors.Wrap(err, "could not get the adopted experiment")
+	}
+	c.trackCreated(gvk, obj, opts)
+	return nil
+}
+
+// getExisting gets the existing experiment as the same type of object the experiments of its kind are created from
+func (c *experimentsConfigurator) getExisting(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions) (client.Object, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opts.injectionTimeout)
+	defer cancel()
+
+	existing := newExperimentObject(gvk)
+	err := c.kubeCli.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the existing experiment")
+	}
+	return existing, nil
+}
+
+// defaultedSpec returns the spec of the experiment with the defaults of the chaos mesh webhooks applied
+func defaultedSpec(gvk schema.GroupVersionKind, obj client.Object) (interface{}, error) {
+	// typed objects read from the cluster have no kind
+	withKind := obj.DeepCopyObject()
+	withKind.GetObjectKind().SetGroupVersionKind(gvk)
+
+	defaulted, err := validation.Default(withKind)
+	if runtime.IsNotRegisteredError(err) {
+		// kinds unknown to this version of chaos mesh are compared as they are
+		defaulted, err = withKind, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	content, err := toUnstructured(defaulted)
+	if err != nil {
+		return nil, err
+	}
+	return content.Object["spec"], nil
+}
+
+func mergeStrings(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = map[string]string{}
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
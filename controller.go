@@ -0,0 +1,165 @@
+package chaosmesh
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/form3tech-oss/f1/pkg/f1/testing"
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExperimentsController pauses and resumes the experiments of a scenario while it runs,
+// through the experiment.chaos-mesh.org/pause annotation
+type ExperimentsController struct {
+	c *experimentsConfigurator
+}
+
+// ExperimentsControllerHook receives the controller of a scenario once its experiments are set up
+type ExperimentsControllerHook func(ctrl *ExperimentsController)
+
+// controllers holds the controller of every scenario being run, by scenario name,
+// the iterations of a scenario get a different *testing.T than its setup
+var controllers = struct {
+	sync.Mutex
+	byScenario map[string]*ExperimentsController
+}{byScenario: map[string]*ExperimentsController{}}
+
+// ControllerFor returns the controller of the experiments of the scenario being run, or nil if it has none
+func ControllerFor(t *testing.T) *ExperimentsController {
+	controllers.Lock()
+	defer controllers.Unlock()
+	return controllers.byScenario[t.Scenario]
+}
+
+func registerController(scenario string, ctrl *ExperimentsController) {
+	controllers.Lock()
+	defer controllers.Unlock()
+	controllers.byScenario[scenario] = ctrl
+}
+
+func unregisterController(scenario string, ctrl *ExperimentsController) {
+	controllers.Lock()
+	defer controllers.Unlock()
+	if controllers.byScenario[scenario] == ctrl {
+		delete(controllers.byScenario, scenario)
+	}
+}
+
+// Pause pauses the experiment with the given name, or namespace/name, and waits for chaos mesh to recover its targets
+func (ctrl *ExperimentsController) Pause(name string) error {
+	exp, err := ctrl.find(name)
+	if err != nil {
+		return err
+	}
+	return ctrl.setPaused(exp, true)
+}
+
+// Resume resumes the experiment with the given name, or namespace/name, and waits for chaos mesh to inject it again
+func (ctrl *ExperimentsController) Resume(name string) error {
+	exp, err := ctrl.find(name)
+	if err != nil {
+		return err
+	}
+	return ctrl.setPaused(exp, false)
+}
+
+// PauseAll pauses every experiment that can be paused, at the same time, workflows and pod chaos kinds are left alone
+func (ctrl *ExperimentsController) PauseAll() error {
+	return ctrl.setAllPaused(true)
+}
+
+// ResumeAll resumes every experiment that can be paused, at the same time
+func (ctrl *ExperimentsController) ResumeAll() error {
+	return ctrl.setAllPaused(false)
+}
+
+func (ctrl *ExperimentsController) setAllPaused(paused bool) error {
+	var pausable []*createdExperiment
+	for _, exp := range ctrl.c.createdExperiments() {
+		if exp.gvk.Kind != chaosmeshv1alpha1.KindWorkflow && !isPodChaos(exp.gvk) {
+			pausable = append(pausable, exp)
+		}
+	}
+
+	errs := make([]error, len(pausable))
+	var wg sync.WaitGroup
+	for i, exp := range pausable {
+		wg.Add(1)
+		go func(i int, exp *createdExperiment) {
+			defer wg.Done()
+			errs[i] = ctrl.setPaused(exp, paused)
+		}(i, exp)
+	}
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (ctrl *ExperimentsController) setPaused(exp *createdExperiment, paused bool) error {
+	expFriendlyName := generateExperimentFriendlyName(exp.gvk.Kind, exp.obj.GetNamespace(), exp.obj.GetName())
+	if exp.gvk.Kind == chaosmeshv1alpha1.KindWorkflow {
+		return errors.Errorf("chaos experiment %s cannot be paused, workflows do not support pausing", expFriendlyName)
+	}
+	if isPodChaos(exp.gvk) {
+		return errors.Errorf("chaos experiment %s cannot be paused, %s does not support pausing", expFriendlyName, exp.gvk.Kind)
+	}
+
+	action, state := "Resuming", "resumed"
+	if paused {
+		action, state = "Pausing", "paused"
+	}
+	ctrl.c.t.Logger.Infof("%s chaos experiment %s", action, expFriendlyName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), exp.opts.injectionTimeout)
+	defer cancel()
+
+	// the tracked object is shared with the cleanup, the patch works on a copy
+	err := ctrl.c.patchPaused(ctx, exp.obj, paused)
+	if err != nil {
+		return errors.Wrapf(err, "could not patch chaos experiment %s", expFriendlyName)
+	}
+
+	// a schedule has no conditions, it stops spawning experiments as soon as it is paused
+	if exp.gvk.Kind == chaosmeshv1alpha1.KindSchedule {
+		return nil
+	}
+
+	err = ctrl.c.waitForExperiment(exp.gvk, exp.obj, exp.opts, state, chaosPaused(paused))
+	if err != nil {
+		return errors.Wrapf(err, "chaos experiment %s was not %s", expFriendlyName, state)
+	}
+	return nil
+}
+
+// find looks up a created experiment by name, or by namespace/name when names are ambiguous
+func (ctrl *ExperimentsController) find(name string) (*createdExperiment, error) {
+	var found []*createdExperiment
+	for _, exp := range ctrl.c.createdExperiments() {
+		if matchesName(exp.obj, name) {
+			found = append(found, exp)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, errors.Errorf("no chaos experiment named %s", name)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, errors.Errorf("%d chaos experiments are named %s, use namespace/name", len(found), name)
+	}
+}
+
+// matchesName reports whether the experiment is named so, by the name it was defined or created with
+func matchesName(obj client.Object, name string) bool {
+	for _, n := range []string{obj.GetName(), definedName(obj)} {
+		if n == name || fmt.Sprintf("%s/%s", obj.GetNamespace(), n) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package chaosmesh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// EnvDryRun enables the dry run mode when the plugin options do not set it, its value is a DryRunMode
+const EnvDryRun = "F1_CHAOS_MESH_DRY_RUN"
+
+// DryRunMode selects what happens to the scenario once its experiments have been rendered
+type DryRunMode string
+
+const (
+	// DryRunDisabled creates the experiments in the cluster
+	DryRunDisabled DryRunMode = ""
+	// DryRunContinue renders the experiments and runs the scenario without chaos
+	DryRunContinue DryRunMode = "continue"
+	// DryRunAbort renders the experiments and stops before the scenario runs
+	DryRunAbort DryRunMode = "abort"
+)
+
+func dryRunModeFromEnv() DryRunMode {
+	switch v := strings.ToLower(os.Getenv(EnvDryRun)); v {
+	case "", "false", "0":
+		return DryRunDisabled
+	case "true", "1":
+		return DryRunContinue
+	default:
+		return DryRunMode(v)
+	}
+}
+
+func (m DryRunMode) validate() error {
+	switch m {
+	case DryRunDisabled, DryRunContinue, DryRunAbort:
+		return nil
+	default:
+		return fmt.Errorf("unknown dry run mode %q, expected %q or %q", m, DryRunContinue, DryRunAbort)
+	}
+}
+
+// DryRunExperiments renders the experiments in apply order without creating them.
+// With a client the server performs a dry run create, so the manifests include the
+// defaults of the chaos mesh webhooks, otherwise they are rendered offline.
+func (c *experimentsConfigurator) DryRunExperiments() error {
+	if c.kubeCli == nil {
+		c.t.Logger.Info("Dry run of chaos experiments, rendering offline")
+	} else {
+		c.t.Logger.Info("Dry run of chaos experiments against the cluster")
+	}
+
+	for _, exp := range c.experiments.experiments {
+		for _, o := range exp.objs {
+			obj := c.nameExperiment(o.obj)
+			c.run.stamp(obj, c.t.Scenario)
+
+			expFriendlyName := generateExperimentFriendlyName(o.gvk.Kind, obj.GetNamespace(), obj.GetName())
+			if c.kubeCli != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), exp.opts.injectionTimeout)
+				err := c.kubeCli.Create(ctx, obj, client.DryRunAll)
+				cancel()
+				if err != nil {
+					return errors.Wrapf(err, "dry run of chaos experiment %s failed", expFriendlyName)
+				}
+			}
+
+			// the client drops the kind of typed objects once they are decoded
+			obj.GetObjectKind().SetGroupVersionKind(o.gvk)
+			obj.SetManagedFields(nil)
+
+			manifest, err := yaml.Marshal(obj)
+			if err != nil {
+				return errors.Wrapf(err, "could not render chaos experiment %s", expFriendlyName)
+			}
+
+			_, err = fmt.Fprintf(c.opts.dryRunOutput, "---\n# %s\n%s", expFriendlyName, manifest)
+			if err != nil {
+				return errors.Wrap(err, "could not write dry run output")
+			}
+		}
+	}
+
+	return nil
+}
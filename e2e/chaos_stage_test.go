@@ -1,7 +1,10 @@
 package e2e
 
 import (
+	"bytes"
 	"context"
+	"embed"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -11,15 +14,21 @@ import (
 	f1Testing "github.com/form3tech-oss/f1/pkg/f1/testing"
 	chaosmesh "github.com/samuel-form3/f1-chaos-mesh"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
+//go:embed manifests/dir
+var experimentsDir embed.FS
+
 type f1ScenariosStage struct {
 	t                   *testing.T
 	runner              *f1.F1
@@ -29,6 +38,57 @@ type f1ScenariosStage struct {
 	watchExperimentsWg  *sync.WaitGroup
 	watchExperimentsErr error
 	k8sClient           client.Client
+
+	experimentsLabeledWithRunID int
+	dryRunOutput                *bytes.Buffer
+	pauseAndResumeErr           error
+	pausePodChaosErr            error
+
+	leftOver        types.NamespacedName
+	leftOverSpawned []types.NamespacedName
+
+	staleExperiment types.NamespacedName
+	freshExperiment types.NamespacedName
+	collected       []string
+	collectErr      error
+
+	readyPredicateCalls int
+	readyPredicateHeld  bool
+
+	workflowAccomplishedAt time.Time
+	workflowDeletedAt      time.Time
+	workflowCompletionWg   sync.WaitGroup
+
+	releases             map[types.NamespacedName]*releaseObservation
+	releasesWg           sync.WaitGroup
+	stopObservingRelease context.CancelFunc
+
+	runDuration            time.Duration
+	timelineObservations   []timelineObservation
+	timelineObservationsWg sync.WaitGroup
+	mu                     sync.Mutex
+	lastIterationAt        time.Duration
+}
+
+// leftOverLatency differs from the latency of the scenario-file manifest
+const leftOverLatency = "50ms"
+
+const (
+	timelineStartAfter = 2 * time.Second
+	timelineActiveFor  = 3 * time.Second
+)
+
+// releaseObservation tells how an experiment that can be paused was created and when it was released
+type releaseObservation struct {
+	createdAt     time.Time
+	createdPaused bool
+	releasedAt    time.Time
+}
+
+// timelineObservation tells whether the timeline experiment existed at some point of the load test
+type timelineObservation struct {
+	at     time.Duration
+	exists bool
 }
 
 func newF1ScenarioStage(t *testing.T) (given, when, then *f1ScenariosStage) {
@@ -47,6 +107,221 @@ func newF1ScenarioStage(t *testing.T) (given, when, then *f1ScenariosStage) {
 	return s, s, s
 }
 
+func (s *f1ScenariosStage) the_chaos_plugin_waits_for_recovery() *f1ScenariosStage {
+	s.chaosPlugin = chaosmesh.NewChaosPlugin(chaosmesh.WithDefaultWaitForRecovery(true))
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_plugin_releases_experiments_all_at_once() *f1ScenariosStage {
+	s.chaosPlugin = chaosmesh.NewChaosPlugin(chaosmesh.WithConcurrency(4), chaosmesh.WithReleaseAllAtOnce(true))
+	return s
+}
+
+// the_release_of_the_experiments_is_observed watches the experiments that can be paused from their creation
+func (s *f1ScenariosStage) the_release_of_the_experiments_is_observed() *f1ScenariosStage {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopObservingRelease = cancel
+	s.releases = map[types.NamespacedName]*releaseObservation{}
+
+	for gvk := range s.expectedExperiments {
+		if gvk.Kind == "Workflow" {
+			continue
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		w, err := k8sWatchClient.Watch(ctx, list, client.InNamespace("kube-system"))
+		require.NoError(s.t, err)
+
+		s.releasesWg.Add(1)
+		go func() {
+			defer s.releasesWg.Done()
+			defer w.Stop()
+			for ev := range w.ResultChan() {
+				obj, ok := ev.Object.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				s.observeRelease(ev.Type, obj)
+			}
+		}()
+	}
+
+	return s
+}
+
+// the_completion_of_the_workflow_is_observed watches the workflow until its deletion starts
+func (s *f1ScenariosStage) the_completion_of_the_workflow_is_observed() *f1ScenariosStage {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+
+	w, err := k8sWatchClient.Watch(ctx, &chaosmeshv1alpha1.WorkflowList{}, client.InNamespace("kube-system"))
+	require.NoError(s.t, err)
+
+	s.workflowCompletionWg.Add(1)
+	go func() {
+		defer s.workflowCompletionWg.Done()
+		defer cancel()
+		defer w.Stop()
+		for ev := range w.ResultChan() {
+			wf, ok := ev.Object.(*chaosmeshv1alpha1.Workflow)
+			if !ok {
+				continue
+			}
+			if s.observeWorkflowCompletion(ev.Type, wf) {
+				return
+			}
+		}
+	}()
+
+	return s
+}
+
+// observeWorkflowCompletion records when the workflow is accomplished and when its deletion starts, it reports the latter
+func (s *f1ScenariosStage) observeWorkflowCompletion(eventType watch.EventType, wf *chaosmeshv1alpha1.Workflow) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.workflowAccomplishedAt.IsZero() {
+		for _, c := range wf.Status.Conditions {
+			if c.Type == chaosmeshv1alpha1.WorkflowConditionAccomplished && c.Status == corev1.ConditionTrue {
+				s.workflowAccomplishedAt = time.Now()
+			}
+		}
+	}
+
+	if eventType == watch.Deleted || wf.DeletionTimestamp != nil {
+		s.workflowDeletedAt = time.Now()
+		return true
+	}
+	return false
+}
+
+func (s *f1ScenariosStage) observeRelease(eventType watch.EventType, obj *unstructured.Unstructured) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, paused := obj.GetAnnotations()[chaosmeshv1alpha1.PauseAnnotationKey]
+	nn := client.ObjectKeyFromObject(obj)
+	switch eventType {
+	case watch.Added:
+		s.releases[nn] = &releaseObservation{createdAt: time.Now(), createdPaused: paused}
+	case watch.Modified:
+		if o, ok := s.releases[nn]; ok && !paused && o.releasedAt.IsZero() {
+			o.releasedAt = time.Now()
+		}
+	}
+}
+
+func (s *f1ScenariosStage) the_chaos_plugin_names_experiments_uniquely_in_a_default_namespace() *f1ScenariosStage {
+	s.chaosPlugin = chaosmesh.NewChaosPlugin(chaosmesh.WithNamingPolicy(chaosmesh.NamingRunIDSuffix), chaosmesh.WithNamespace("kube-system"))
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_plugin_replaces_existing_experiments() *f1ScenariosStage {
+	s.chaosPlugin = chaosmesh.NewChaosPlugin(chaosmesh.WithConflictPolicy(chaosmesh.ConflictReplace))
+	return s
+}
+
+func (s *f1ScenariosStage) a_chaos_experiment_was_left_over_by_a_previous_run() *f1ScenariosStage {
+	content, err := os.ReadFile("./manifests/scenario-file.yaml")
+	require.NoError(s.t, err)
+
+	leftOver := &unstructured.Unstructured{}
+	require.NoError(s.t, yaml.Unmarshal(content, &leftOver.Object))
+	s.leaveOver(leftOver)
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_plugin_adopts_existing_experiments() *f1ScenariosStage {
+	s.chaosPlugin = chaosmesh.NewChaosPlugin(chaosmesh.WithConflictPolicy(chaosmesh.ConflictAdopt))
+	return s
+}
+
+func (s *f1ScenariosStage) a_chaos_experiment_with_a_different_spec_was_left_over_by_a_previous_run() *f1ScenariosStage {
+	content, err := os.ReadFile("./manifests/scenario-file.yaml")
+	require.NoError(s.t, err)
+
+	leftOver := &unstructured.Unstructured{}
+	require.NoError(s.t, yaml.Unmarshal(content, &leftOver.Object))
+	require.NoError(s.t, unstructured.SetNestedField(leftOver.Object, leftOverLatency, "spec", "delay", "latency"))
+	s.leaveOver(leftOver)
+
+	s.leftOver = client.ObjectKeyFromObject(leftOver)
+	return s
+}
+
+func (s *f1ScenariosStage) a_chaos_schedule_and_its_experiments_were_left_over_by_a_previous_run() *f1ScenariosStage {
+	content, err := os.ReadFile("./manifests/schedule-file.yaml")
+	require.NoError(s.t, err)
+
+	leftOver := &unstructured.Unstructured{}
+	require.NoError(s.t, yaml.Unmarshal(content, &leftOver.Object))
+	s.leaveOver(leftOver)
+
+	// an experiment spawned by the schedule, schedules label them with their name
+	spawned := &unstructured.Unstructured{}
+	spawned.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos"))
+	spawned.SetName(leftOver.GetName() + "-left-over")
+	spawned.SetNamespace(leftOver.GetNamespace())
+	spawned.SetLabels(map[string]string{chaosmeshv1alpha1.LabelManagedBy: leftOver.GetName()})
+	podChaos, _, err := unstructured.NestedMap(leftOver.Object, "spec", "podChaos")
+	require.NoError(s.t, err)
+	require.NoError(s.t, unstructured.SetNestedMap(spawned.Object, podChaos, "spec"))
+	s.leaveOver(spawned)
+
+	s.leftOverSpawned = append(s.leftOverSpawned, client.ObjectKeyFromObject(spawned))
+	return s
+}
+
+func (s *f1ScenariosStage) a_stale_and_a_fresh_experiment_were_left_over_by_previous_runs() *f1ScenariosStage {
+	s.staleExperiment = s.leaveOverFromRun("gc-stale", time.Now().Add(-2*time.Hour), time.Hour)
+	s.freshExperiment = s.leaveOverFromRun("gc-fresh", time.Now(), 24*time.Hour)
+	return s
+}
+
+// leaveOverFromRun leaves an experiment labeled by a run of another host, started at the given time
+func (s *f1ScenariosStage) leaveOverFromRun(name string, started time.Time, ttl time.Duration) types.NamespacedName {
+	content, err := os.ReadFile("./manifests/scenario-file.yaml")
+	require.NoError(s.t, err)
+
+	leftOver := &unstructured.Unstructured{}
+	require.NoError(s.t, yaml.Unmarshal(content, &leftOver.Object))
+	leftOver.SetName(name)
+	leftOver.SetLabels(map[string]string{
+		chaosmesh.LabelManagedBy: "f1-chaos-mesh",
+		chaosmesh.LabelRunID:     name,
+	})
+	leftOver.SetAnnotations(map[string]string{
+		chaosmesh.AnnotationHost:      "another-host",
+		chaosmesh.AnnotationPID:       "1",
+		chaosmesh.AnnotationStartTime: started.UTC().Format(time.RFC3339),
+		chaosmesh.AnnotationTTL:       ttl.String(),
+	})
+	s.leaveOver(leftOver)
+
+	return client.ObjectKeyFromObject(leftOver)
+}
+
+func (s *f1ScenariosStage) leaveOver(obj *unstructured.Unstructured) {
+	require.NoError(s.t, s.k8sClient.Create(context.Background(), obj))
+
+	s.t.Cleanup(func() {
+		_ = client.IgnoreNotFound(s.k8sClient.Delete(context.Background(), obj))
+	})
+}
+
+func (s *f1ScenariosStage) the_chaos_plugin_dry_runs_and_continues() *f1ScenariosStage {
+	s.dryRunOutput = &bytes.Buffer{}
+	s.chaosPlugin = chaosmesh.NewChaosPlugin(chaosmesh.WithDryRun(chaosmesh.DryRunContinue), chaosmesh.WithDryRunOutput(s.dryRunOutput))
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_plugin_dry_runs_and_aborts() *f1ScenariosStage {
+	s.dryRunOutput = &bytes.Buffer{}
+	s.chaosPlugin = chaosmesh.NewChaosPlugin(chaosmesh.WithDryRun(chaosmesh.DryRunAbort), chaosmesh.WithDryRunOutput(s.dryRunOutput))
+	return s
+}
+
 func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_struct_chaos_experiment() *f1ScenariosStage {
 	s.runner.Add(
 		"exampleWithChaos",
@@ -86,6 +361,86 @@ func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_struct_chao
 	return s
 }
 
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_built_chaos_experiments() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.Network("scenario-network-builder").
+				InNamespace("kube-system").
+				WithLabels(map[string]string{"k8s-app": "kube-dns"}).
+				Delay(10 * time.Millisecond).
+				Jitter(time.Millisecond)
+			b.Stress("scenario-stress-builder").
+				InNamespace("kube-system").
+				WithLabels(map[string]string{"k8s-app": "kube-dns"}).
+				Mode(chaosmeshv1alpha1.OneMode).
+				CPU(1, 10)
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-network-builder", Namespace: "kube-system"},
+	}
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("StressChaos")] = []types.NamespacedName{
+		{Name: "scenario-stress-builder", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_chaos_experiment_without_namespace() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.Network("scenario-unique").
+				InNamespaces("kube-system").
+				WithLabels(map[string]string{"k8s-app": "kube-dns"}).
+				Delay(10 * time.Millisecond)
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-unique-" + s.chaosPlugin.RunID(), Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_templated_file_chaos_experiment() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithTemplateValues(map[string]interface{}{
+				"name":      "scenario-templated",
+				"namespace": "kube-system",
+				"labels":    map[string]string{"k8s-app": "kube-dns"},
+			}).
+				WithNetworkChaosFromFile("./manifests/templated-file.yaml", chaosmesh.WithTemplate(true))
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-templated-" + s.chaosPlugin.RunID(), Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_that_counts_the_experiments_of_its_run() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		s.countExperimentsLabeledWithRunIDScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithNetworkChaosFromFile("./manifests/scenario-file.yaml")
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-file", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
 func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_yaml_chaos_experiment() *f1ScenariosStage {
 	s.runner.Add(
 		"exampleWithChaos",
@@ -196,6 +551,39 @@ func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_struct_chao
 	return s
 }
 
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_built_chaos_workflow_experiment() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithChaosWorkflowBuilder(chaosmesh.NewWorkflowBuilder("workflow-builder", "kube-system").
+				Entry(chaosmesh.Serial(
+					chaosmesh.Schedule("@every 20s", chaosmesh.PodChaosNode(chaosmeshv1alpha1.PodChaosSpec{
+						Action: chaosmeshv1alpha1.PodKillAction,
+						ContainerSelector: chaosmeshv1alpha1.ContainerSelector{
+							PodSelector: chaosmeshv1alpha1.PodSelector{
+								Mode: chaosmeshv1alpha1.OneMode,
+								Selector: chaosmeshv1alpha1.PodSelectorSpec{
+									GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
+										Namespaces: []string{"kube-system"},
+										LabelSelectors: map[string]string{
+											"k8s-app": "kube-dns",
+										},
+									},
+								},
+							},
+						},
+					})).Deadline(40 * time.Second),
+				).Deadline(5 * time.Minute)))
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("Workflow")] = []types.NamespacedName{
+		{Name: "workflow-builder", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
 func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_yaml_chaos_workflow_experiment() *f1ScenariosStage {
 	s.runner.Add(
 		"exampleWithChaos",
@@ -255,11 +643,354 @@ func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_file_chaos_
 	return s
 }
 
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_experiments_that_choose_their_readiness() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithNetworkChaosFromFile("./manifests/scenario-file.yaml",
+				chaosmesh.WithReadyConditions(chaosmeshv1alpha1.ConditionSelected),
+				chaosmesh.WithReadyWhen(func(live *unstructured.Unstructured) (bool, error) {
+					records, _, err := unstructured.NestedSlice(live.Object, "status", "experiment", "containerRecords")
+					s.mu.Lock()
+					defer s.mu.Unlock()
+					s.readyPredicateCalls++
+					s.readyPredicateHeld = s.readyPredicateHeld || len(records) > 0
+					return len(records) > 0, err
+				})).
+				WithChaosWorkflowFromFile("./manifests/workflow-file.yaml",
+					chaosmesh.WithReadyWorkflowNodes("workflow-pod-chaos-schedule"))
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-file", Namespace: "kube-system"},
+	}
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("Workflow")] = []types.NamespacedName{
+		{Name: "workflow-file", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_chaos_experiment_on_a_timeline() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		s.observeTimelineScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithNetworkChaosFromFile("./manifests/scenario-file.yaml",
+				chaosmesh.WithStartAfter(timelineStartAfter),
+				chaosmesh.WithActiveFor(timelineActiveFor))
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-file", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_chaos_workflow_that_completes_before_cleanup() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithChaosWorkflowFromFile("./manifests/short-workflow-file.yaml",
+				chaosmesh.WithWaitForWorkflowCompletion(true),
+				chaosmesh.WithWorkflowNodeFailurePolicy(chaosmesh.FailOnWorkflowNodeFailure))
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("Workflow")] = []types.NamespacedName{
+		{Name: "short-workflow-file", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_that_pauses_and_resumes_its_experiments() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		s.pauseAndResumeExperimentsScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithNetworkChaosFromFile("./manifests/scenario-file.yaml").
+				WithScheduleFromFile("./manifests/schedule-file.yaml").
+				WithPodNetworkChaos(&chaosmeshv1alpha1.PodNetworkChaos{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod-network-chaos-struct",
+						Namespace: "kube-system",
+					},
+				}, chaosmesh.WithReadyWhen(func(*unstructured.Unstructured) (bool, error) {
+					// no pod is named after it, the chaos daemon never applies it
+					return true, nil
+				}))
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-file", Namespace: "kube-system"},
+	}
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("PodNetworkChaos")] = []types.NamespacedName{
+		{Name: "pod-network-chaos-struct", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_struct_chaos_schedule_experiment() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithSchedule(&chaosmeshv1alpha1.Schedule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "schedule-struct",
+					Namespace: "kube-system",
+				},
+				Spec: chaosmeshv1alpha1.ScheduleSpec{
+					Schedule:          "@every 2s",
+					Type:              chaosmeshv1alpha1.ScheduleTypePodChaos,
+					ConcurrencyPolicy: chaosmeshv1alpha1.ForbidConcurrent,
+					HistoryLimit:      1,
+					ScheduleItem: chaosmeshv1alpha1.ScheduleItem{
+						EmbedChaos: chaosmeshv1alpha1.EmbedChaos{
+							PodChaos: &chaosmeshv1alpha1.PodChaosSpec{
+								Action: chaosmeshv1alpha1.PodKillAction,
+								ContainerSelector: chaosmeshv1alpha1.ContainerSelector{
+									PodSelector: chaosmeshv1alpha1.PodSelector{
+										Mode: chaosmeshv1alpha1.OneMode,
+										Selector: chaosmeshv1alpha1.PodSelectorSpec{
+											GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
+												Namespaces: []string{"kube-system"},
+												LabelSelectors: map[string]string{
+													"k8s-app": "kube-dns",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("Schedule")] = []types.NamespacedName{
+		{Name: "schedule-struct", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_file_chaos_schedule_experiment() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithScheduleFromFile("./manifests/schedule-file.yaml")
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("Schedule")] = []types.NamespacedName{
+		{Name: "schedule-file", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_file_and_yaml_chaos_experiments_of_different_kinds() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithChaosWorkflowFromFile("./manifests/workflow-file.yaml").
+				WithNetworkChaosFromFile("./manifests/scenario-file.yaml").
+				WithNetworkChaosFromYaml(`
+apiVersion: chaos-mesh.org/v1alpha1
+kind: NetworkChaos
+metadata:
+  name: scenario-yaml
+  namespace: kube-system
+spec:
+  action: delay
+  mode: one
+  selector:
+    namespaces:
+      - kube-system
+    labelSelectors:
+      "k8s-app": "kube-dns"
+  delay:
+    latency: '10ms'
+`)
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("Workflow")] = []types.NamespacedName{
+		{Name: "workflow-file", Namespace: "kube-system"},
+	}
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-file", Namespace: "kube-system"},
+		{Name: "scenario-yaml", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_chaos_experiment_that_cannot_be_created() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithNetworkChaosFromFile("./manifests/scenario-file.yaml").
+				WithNetworkChaosFromYaml(`
+apiVersion: chaos-mesh.org/v1alpha1
+kind: NetworkChaos
+metadata:
+  name: scenario-rejected
+  namespace: f1-chaos-mesh-missing
+spec:
+  action: delay
+  mode: one
+  selector:
+    namespaces:
+      - kube-system
+  delay:
+    latency: '10ms'
+`)
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-file", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_multi_document_file_of_any_chaos_kind() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithChaosFromFile("./manifests/multi-document-file.yaml")
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "multi-document-network", Namespace: "kube-system"},
+	}
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos")] = []types.NamespacedName{
+		{Name: "multi-document-pod", Namespace: "kube-system"},
+	}
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("Workflow")] = []types.NamespacedName{
+		{Name: "multi-document-workflow", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_the_experiments_of_a_directory() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithExperimentsFromDir("./manifests/dir")
+		}))
+
+	return s.the_experiments_of_the_directory_are_expected()
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_the_experiments_of_an_embedded_directory() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithExperimentsFromFS(experimentsDir, "manifests/dir/*")
+		}))
+
+	return s.the_experiments_of_the_directory_are_expected()
+}
+
+func (s *f1ScenariosStage) the_experiments_of_the_directory_are_expected() *f1ScenariosStage {
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "dir-network", Namespace: "kube-system"},
+	}
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos")] = []types.NamespacedName{
+		{Name: "dir-pod", Namespace: "kube-system"},
+	}
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("Workflow")] = []types.NamespacedName{
+		{Name: "dir-workflow", Namespace: "kube-system"},
+	}
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("StressChaos")] = []types.NamespacedName{
+		{Name: "dir-stress", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_a_struct_chaos_experiment_of_any_kind() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithChaos(&chaosmeshv1alpha1.PodChaos{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "any-kind-struct",
+					Namespace: "kube-system",
+				},
+				Spec: chaosmeshv1alpha1.PodChaosSpec{
+					Action:   chaosmeshv1alpha1.PodFailureAction,
+					Duration: strPtr("30s"),
+					ContainerSelector: chaosmeshv1alpha1.ContainerSelector{
+						PodSelector: chaosmeshv1alpha1.PodSelector{
+							Mode: chaosmeshv1alpha1.OneMode,
+							Selector: chaosmeshv1alpha1.PodSelectorSpec{
+								GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
+									Namespaces: []string{"kube-system"},
+									LabelSelectors: map[string]string{
+										"k8s-app": "kube-dns",
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos")] = []types.NamespacedName{
+		{Name: "any-kind-struct", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
+func (s *f1ScenariosStage) f1_is_configured_to_run_a_scenario_with_invalid_chaos_experiments() *f1ScenariosStage {
+	s.runner.Add(
+		"exampleWithChaos",
+		noopScenario,
+		s.chaosPlugin.WithExperiments(func(b *chaosmesh.ChaosExperimentsBuilder) {
+			b.WithNetworkChaosFromFile("./manifests/scenario-file.yaml").
+				WithNetworkChaosFromFile("./manifests/does-not-exist.yaml").
+				WithPodChaosFromFile("./manifests/scenario-file.yaml")
+		}))
+
+	s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos")] = []types.NamespacedName{
+		{Name: "scenario-file", Namespace: "kube-system"},
+	}
+
+	return s
+}
+
 func (s *f1ScenariosStage) the_f1_scenario_is_executed() *f1ScenariosStage {
+	return s.the_f1_scenario_is_executed_for(5 * time.Second)
+}
+
+// the_f1_scenario_is_executed_beyond_the_timeline leaves time for the experiment to be removed, and observed as such, before the load test ends
+func (s *f1ScenariosStage) the_f1_scenario_is_executed_beyond_the_timeline() *f1ScenariosStage {
+	return s.the_f1_scenario_is_executed_for(15 * time.Second)
+}
+
+func (s *f1ScenariosStage) the_f1_scenario_is_executed_for(duration time.Duration) *f1ScenariosStage {
+	s.runDuration = duration
 	s.runErr = s.runner.ExecuteWithArgs([]string{
 		"run", "constant",
 		"--rate", "1/s",
-		"--max-duration", "5s",
+		"--max-duration", duration.String(),
 		"--verbose",
 		"exampleWithChaos",
 	})
@@ -267,17 +998,53 @@ func (s *f1ScenariosStage) the_f1_scenario_is_executed() *f1ScenariosStage {
 	return s
 }
 
+func (s *f1ScenariosStage) the_experiments_are_garbage_collected() *f1ScenariosStage {
+	s.collected, s.collectErr = s.chaosPlugin.GarbageCollect(context.Background())
+	return s
+}
+
 func (s *f1ScenariosStage) the_f1_scenario_succeeds() *f1ScenariosStage {
 	require.NoError(s.t, s.runErr, "error executing scenarios")
 	return s
 }
 
+func (s *f1ScenariosStage) the_f1_scenario_fails() *f1ScenariosStage {
+	require.Error(s.t, s.runErr, "scenario was expected to fail")
+	return s
+}
+
+func (s *f1ScenariosStage) no_chaos_experiment_was_created() *f1ScenariosStage {
+	s.watchExperimentsWg.Wait()
+	require.Error(s.t, s.watchExperimentsErr, "experiments were created")
+	return s
+}
+
 func (s *f1ScenariosStage) the_chaos_experiments_are_created() *f1ScenariosStage {
 	s.watchExperimentsWg.Wait()
 	require.NoError(s.t, s.watchExperimentsErr, "error ensuring experiments were created")
 	return s
 }
 
+func (s *f1ScenariosStage) the_chaos_workflow_was_accomplished_before_it_was_deleted() *f1ScenariosStage {
+	s.workflowCompletionWg.Wait()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	require.False(s.t, s.workflowDeletedAt.IsZero(), "the workflow was never deleted")
+	require.False(s.t, s.workflowAccomplishedAt.IsZero(), "the workflow was deleted before it was accomplished")
+	require.False(s.t, s.workflowAccomplishedAt.After(s.workflowDeletedAt), "the workflow was deleted before it was accomplished")
+	return s
+}
+
+func (s *f1ScenariosStage) the_ready_predicate_decided_when_the_experiment_was_ready() *f1ScenariosStage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	require.NotZero(s.t, s.readyPredicateCalls, "the ready predicate was never called")
+	require.True(s.t, s.readyPredicateHeld, "the experiment was considered ready before the ready predicate held")
+	return s
+}
+
 func (s *f1ScenariosStage) the_chaos_experiments_are_cleaned_up() *f1ScenariosStage {
 	err := wait.PollImmediate(1*time.Second, 20*time.Second, func() (bool, error) {
 		for gvk, n := range s.expectedExperiments {
@@ -297,6 +1064,188 @@ func (s *f1ScenariosStage) the_chaos_experiments_are_cleaned_up() *f1ScenariosSt
 	return s
 }
 
+func (s *f1ScenariosStage) the_chaos_schedule_children_are_cleaned_up() *f1ScenariosStage {
+	err := wait.PollImmediate(1*time.Second, 20*time.Second, func() (bool, error) {
+		for _, nn := range s.expectedExperiments[chaosmeshv1alpha1.GroupVersion.WithKind("Schedule")] {
+			children := &unstructured.UnstructuredList{}
+			children.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind("PodChaosList"))
+			err := s.k8sClient.List(
+				context.Background(),
+				children,
+				client.InNamespace(nn.Namespace),
+				client.MatchingLabels{chaosmeshv1alpha1.LabelManagedBy: nn.Name})
+			if err != nil || len(children.Items) > 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+
+	require.NoError(s.t, err, "error ensuring schedule children were cleaned up")
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_experiments_were_removed_when_the_scenario_finished() *f1ScenariosStage {
+	for gvk, n := range s.expectedExperiments {
+		for _, nn := range n {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvk)
+			err := s.k8sClient.Get(context.Background(), nn, obj)
+			require.True(s.t, apierrors.IsNotFound(err), "experiment %s was not removed", nn)
+		}
+	}
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_experiment_was_not_created_before_it_started() *f1ScenariosStage {
+	s.timelineObservationsWg.Wait()
+
+	before := 0
+	for _, o := range s.timelineObservations {
+		if o.at < timelineStartAfter {
+			before++
+			require.False(s.t, o.exists, "experiment existed %s after the load test started", o.at)
+		}
+	}
+	require.NotZero(s.t, before, "experiment was not observed before it started")
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_experiment_was_created_once_it_started() *f1ScenariosStage {
+	s.timelineObservationsWg.Wait()
+
+	for _, o := range s.timelineObservations {
+		if o.at >= timelineStartAfter && o.exists {
+			return s
+		}
+	}
+	require.Fail(s.t, "experiment was not created after it started")
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_experiment_was_removed_while_the_load_test_was_running() *f1ScenariosStage {
+	s.timelineObservationsWg.Wait()
+
+	created := false
+	for _, o := range s.timelineObservations {
+		if o.exists {
+			created = true
+			continue
+		}
+		if created && o.at < s.lastIteration() {
+			require.GreaterOrEqual(s.t, o.at, timelineStartAfter+timelineActiveFor, "experiment was removed before it expired")
+			return s
+		}
+	}
+	require.Fail(s.t, "experiment was not removed while the load test was running")
+	return s
+}
+
+func (s *f1ScenariosStage) the_left_over_experiment_was_not_adopted() *f1ScenariosStage {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"))
+	require.NoError(s.t, s.k8sClient.Get(context.Background(), s.leftOver, obj))
+
+	latency, _, err := unstructured.NestedString(obj.Object, "spec", "delay", "latency")
+	require.NoError(s.t, err)
+	require.Equal(s.t, leftOverLatency, latency, "the left over experiment was changed")
+	require.NotContains(s.t, obj.GetLabels(), chaosmesh.LabelRunID, "the left over experiment was labeled with the run id")
+	return s
+}
+
+func (s *f1ScenariosStage) the_stale_experiment_is_deleted() *f1ScenariosStage {
+	require.NoError(s.t, s.collectErr, "error garbage collecting experiments")
+	require.Contains(s.t, s.collected, "[NetworkChaos]::"+s.staleExperiment.String())
+
+	err := wait.PollImmediate(1*time.Second, 20*time.Second, func() (bool, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"))
+		err := s.k8sClient.Get(context.Background(), s.staleExperiment, obj)
+		return apierrors.IsNotFound(err), nil
+	})
+	require.NoError(s.t, err, "stale experiment %s was not deleted", s.staleExperiment)
+	return s
+}
+
+func (s *f1ScenariosStage) the_fresh_experiment_is_left_alone() *f1ScenariosStage {
+	require.NotContains(s.t, s.collected, "[NetworkChaos]::"+s.freshExperiment.String())
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"))
+	err := s.k8sClient.Get(context.Background(), s.freshExperiment, obj)
+	require.NoError(s.t, err, "fresh experiment %s was deleted", s.freshExperiment)
+	require.Nil(s.t, obj.GetDeletionTimestamp(), "fresh experiment %s is being deleted", s.freshExperiment)
+	return s
+}
+
+func (s *f1ScenariosStage) the_left_over_schedule_experiments_were_removed() *f1ScenariosStage {
+	for _, nn := range s.leftOverSpawned {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos"))
+		err := s.k8sClient.Get(context.Background(), nn, obj)
+		require.True(s.t, apierrors.IsNotFound(err), "experiment %s spawned by the left over schedule was not removed", nn)
+	}
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_experiments_were_created_paused_and_released_together() *f1ScenariosStage {
+	s.stopObservingRelease()
+	s.releasesWg.Wait()
+
+	var lastCreated, firstReleased, lastReleased time.Time
+	for gvk, n := range s.expectedExperiments {
+		if gvk.Kind == "Workflow" {
+			continue
+		}
+		for _, nn := range n {
+			o, ok := s.releases[nn]
+			require.True(s.t, ok, "creation of experiment %s was not observed", nn)
+			require.True(s.t, o.createdPaused, "experiment %s was not created paused", nn)
+			require.False(s.t, o.releasedAt.IsZero(), "experiment %s was not released", nn)
+
+			if o.createdAt.After(lastCreated) {
+				lastCreated = o.createdAt
+			}
+			if firstReleased.IsZero() || o.releasedAt.Before(firstReleased) {
+				firstReleased = o.releasedAt
+			}
+			if o.releasedAt.After(lastReleased) {
+				lastReleased = o.releasedAt
+			}
+		}
+	}
+
+	require.False(s.t, firstReleased.Before(lastCreated), "an experiment was released before every experiment was created")
+	require.Less(s.t, lastReleased.Sub(firstReleased), 2*time.Second, "experiments were not released together")
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_experiments_were_labeled_with_the_run_id() *f1ScenariosStage {
+	require.Equal(s.t, 1, s.experimentsLabeledWithRunID, "experiments labeled with the run id")
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_experiments_manifests_are_printed() *f1ScenariosStage {
+	for gvk, n := range s.expectedExperiments {
+		for _, nn := range n {
+			require.Contains(s.t, s.dryRunOutput.String(), "# ["+gvk.Kind+"]::"+nn.String(), "manifest of %s was not printed", nn)
+		}
+	}
+	require.Contains(s.t, s.dryRunOutput.String(), chaosmesh.LabelRunID+": "+s.chaosPlugin.RunID())
+	return s
+}
+
+func (s *f1ScenariosStage) the_chaos_experiments_were_paused_and_resumed() *f1ScenariosStage {
+	require.NoError(s.t, s.pauseAndResumeErr, "error pausing and resuming experiments")
+	return s
+}
+
+func (s *f1ScenariosStage) the_pod_chaos_experiment_could_not_be_paused() *f1ScenariosStage {
+	require.Error(s.t, s.pausePodChaosErr, "the pod chaos experiment was paused")
+	require.Contains(s.t, s.pausePodChaosErr.Error(), "PodNetworkChaos does not support pausing")
+	return s
+}
+
 func (s *f1ScenariosStage) and() *f1ScenariosStage {
 	return s
 }
@@ -319,6 +1268,68 @@ func (s *f1ScenariosStage) watchExperiments() {
 	})
 }
 
+func (s *f1ScenariosStage) countExperimentsLabeledWithRunIDScenario(t *f1Testing.T) f1Testing.RunFn {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaosList"))
+	err := s.k8sClient.List(context.Background(), list, client.MatchingLabels{chaosmesh.LabelRunID: s.chaosPlugin.RunID()})
+	t.Require.NoError(err)
+	s.experimentsLabeledWithRunID = len(list.Items)
+
+	return noopScenario(t)
+}
+
+func (s *f1ScenariosStage) pauseAndResumeExperimentsScenario(t *f1Testing.T) f1Testing.RunFn {
+	ctrl := chaosmesh.ControllerFor(t)
+	t.Require.NotNil(ctrl)
+
+	s.pausePodChaosErr = ctrl.Pause("pod-network-chaos-struct")
+
+	s.pauseAndResumeErr = ctrl.Pause("scenario-file")
+	if s.pauseAndResumeErr == nil {
+		s.pauseAndResumeErr = ctrl.Resume("scenario-file")
+	}
+	if s.pauseAndResumeErr == nil {
+		s.pauseAndResumeErr = ctrl.PauseAll()
+	}
+	if s.pauseAndResumeErr == nil {
+		s.pauseAndResumeErr = ctrl.ResumeAll()
+	}
+
+	return noopScenario(t)
+}
+
+// observeTimelineScenario records whether the timeline experiment exists, from the start of the load test until it ends
+func (s *f1ScenariosStage) observeTimelineScenario(t *f1Testing.T) f1Testing.RunFn {
+	start := time.Now()
+	nn := types.NamespacedName{Name: "scenario-file", Namespace: "kube-system"}
+
+	s.timelineObservationsWg.Add(1)
+	go func() {
+		defer s.timelineObservationsWg.Done()
+		for at := time.Duration(0); at < s.runDuration; at = time.Since(start) {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"))
+			err := s.k8sClient.Get(context.Background(), nn, obj)
+			if err == nil || apierrors.IsNotFound(err) {
+				s.timelineObservations = append(s.timelineObservations, timelineObservation{at: at, exists: err == nil})
+			}
+			time.Sleep(250 * time.Millisecond)
+		}
+	}()
+
+	return func(t *f1Testing.T) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.lastIterationAt = time.Since(start)
+	}
+}
+
+func (s *f1ScenariosStage) lastIteration() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastIterationAt
+}
+
 func noopScenario(t *f1Testing.T) f1Testing.RunFn {
 	runFn := func(t *f1Testing.T) {}
 	return runFn
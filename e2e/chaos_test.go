@@ -53,6 +53,139 @@ func TestFileExperiment(t *testing.T) {
 		the_chaos_experiments_are_cleaned_up()
 }
 
+func TestBuiltExperiments(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_built_chaos_experiments()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestUniqueNamesInDefaultNamespace(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		the_chaos_plugin_names_experiments_uniquely_in_a_default_namespace().
+		and().
+		f1_is_configured_to_run_a_scenario_with_a_chaos_experiment_without_namespace()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestLeftOverExperimentIsReplaced(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		the_chaos_plugin_replaces_existing_experiments().
+		and().
+		a_chaos_experiment_was_left_over_by_a_previous_run().
+		and().
+		f1_is_configured_to_run_a_scenario_with_a_file_chaos_experiment()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestLeftOverExperimentWithADifferentSpecIsNotAdopted(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		the_chaos_plugin_adopts_existing_experiments().
+		and().
+		a_chaos_experiment_with_a_different_spec_was_left_over_by_a_previous_run().
+		and().
+		f1_is_configured_to_run_a_scenario_with_a_file_chaos_experiment()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_fails().
+		and().
+		the_left_over_experiment_was_not_adopted()
+}
+
+func TestLeftOverScheduleIsReplaced(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		the_chaos_plugin_replaces_existing_experiments().
+		and().
+		a_chaos_schedule_and_its_experiments_were_left_over_by_a_previous_run().
+		and().
+		f1_is_configured_to_run_a_scenario_with_a_file_chaos_schedule_experiment()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_left_over_schedule_experiments_were_removed().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up().
+		and().
+		the_chaos_schedule_children_are_cleaned_up()
+}
+
+func TestGarbageCollectStaleExperiments(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		a_stale_and_a_fresh_experiment_were_left_over_by_previous_runs()
+
+	when.
+		the_experiments_are_garbage_collected()
+
+	then.
+		the_stale_experiment_is_deleted().
+		and().
+		the_fresh_experiment_is_left_alone()
+}
+
+func TestTemplatedFileExperiment(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_a_templated_file_chaos_experiment()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
 func TestStructWorkflow(t *testing.T) {
 	given, when, then := newF1ScenarioStage(t)
 
@@ -70,6 +203,23 @@ func TestStructWorkflow(t *testing.T) {
 		the_chaos_experiments_are_cleaned_up()
 }
 
+func TestBuiltWorkflow(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_a_built_chaos_workflow_experiment()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
 func TestFileWorkflow(t *testing.T) {
 	given, when, then := newF1ScenarioStage(t)
 
@@ -103,3 +253,335 @@ func TestYamlWorkflow(t *testing.T) {
 		and().
 		the_chaos_experiments_are_cleaned_up()
 }
+
+func TestStructSchedule(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_a_struct_chaos_schedule_experiment()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up().
+		and().
+		the_chaos_schedule_children_are_cleaned_up()
+}
+
+func TestFileSchedule(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_a_file_chaos_schedule_experiment()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up().
+		and().
+		the_chaos_schedule_children_are_cleaned_up()
+}
+
+func TestMixedExperiments(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_file_and_yaml_chaos_experiments_of_different_kinds()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestRollbackOnFailure(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_a_chaos_experiment_that_cannot_be_created()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_fails().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestWaitForRecovery(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		the_chaos_plugin_waits_for_recovery().
+		and().
+		f1_is_configured_to_run_a_scenario_with_a_struct_chaos_experiment()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_were_removed_when_the_scenario_finished()
+}
+
+func TestExperimentsAreLabeledWithRunMetadata(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_that_counts_the_experiments_of_its_run()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_were_labeled_with_the_run_id().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestMultiDocumentFileExperiments(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_a_multi_document_file_of_any_chaos_kind()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestExperimentsFromDir(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_the_experiments_of_a_directory()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestExperimentsFromEmbeddedFS(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_the_experiments_of_an_embedded_directory()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestStructExperimentOfAnyKind(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_a_struct_chaos_experiment_of_any_kind()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_chaos_experiments_are_created().
+		and().
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestInvalidExperimentsFailAtSetup(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_invalid_chaos_experiments()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_fails().
+		and().
+		no_chaos_experiment_was_created()
+}
+
+func TestDryRunContinuesWithoutChaos(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		the_chaos_plugin_dry_runs_and_continues().
+		and().
+		f1_is_configured_to_run_a_scenario_with_file_and_yaml_chaos_experiments_of_different_kinds()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_manifests_are_printed().
+		and().
+		no_chaos_experiment_was_created()
+}
+
+func TestDryRunAborts(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		the_chaos_plugin_dry_runs_and_aborts().
+		and().
+		f1_is_configured_to_run_a_scenario_with_a_file_chaos_experiment()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_fails().
+		and().
+		the_chaos_experiments_manifests_are_printed().
+		and().
+		no_chaos_experiment_was_created()
+}
+
+func TestReleaseExperimentsAllAtOnce(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		the_chaos_plugin_releases_experiments_all_at_once().
+		and().
+		f1_is_configured_to_run_a_scenario_with_file_and_yaml_chaos_experiments_of_different_kinds().
+		and().
+		the_release_of_the_experiments_is_observed()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_created().
+		and().
+		the_chaos_experiments_were_created_paused_and_released_together().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestExperimentsChooseTheirReadiness(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_experiments_that_choose_their_readiness()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_created().
+		and().
+		the_ready_predicate_decided_when_the_experiment_was_ready().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestExperimentOnATimeline(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_a_chaos_experiment_on_a_timeline()
+
+	when.
+		the_f1_scenario_is_executed_beyond_the_timeline()
+
+	then.
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_created().
+		and().
+		the_chaos_experiment_was_not_created_before_it_started().
+		and().
+		the_chaos_experiment_was_created_once_it_started().
+		and().
+		the_chaos_experiment_was_removed_while_the_load_test_was_running().
+		and().
+		the_chaos_experiments_were_removed_when_the_scenario_finished()
+}
+
+func TestPauseAndResumeExperiments(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_that_pauses_and_resumes_its_experiments()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_were_paused_and_resumed().
+		and().
+		the_pod_chaos_experiment_could_not_be_paused().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
+
+func TestWorkflowCompletesBeforeCleanup(t *testing.T) {
+	given, when, then := newF1ScenarioStage(t)
+
+	given.
+		f1_is_configured_to_run_a_scenario_with_a_chaos_workflow_that_completes_before_cleanup().
+		and().
+		the_completion_of_the_workflow_is_observed()
+
+	when.
+		the_f1_scenario_is_executed()
+
+	then.
+		the_f1_scenario_succeeds().
+		and().
+		the_chaos_experiments_are_created().
+		and().
+		the_chaos_workflow_was_accomplished_before_it_was_deleted().
+		and().
+		the_chaos_experiments_are_cleaned_up()
+}
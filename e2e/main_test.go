@@ -13,7 +13,8 @@ import (
 )
 
 var (
-	k8sClient client.Client
+	k8sClient      client.Client
+	k8sWatchClient client.WithWatch
 )
 
 func TestMain(m *testing.M) {
@@ -50,6 +51,11 @@ func StartEnv() error {
 		return err
 	}
 
+	k8sWatchClient, err = client.NewWithWatch(config.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/form3tech-oss/f1/pkg/f1"
 	"github.com/form3tech-oss/f1/pkg/f1/testing"
@@ -13,11 +15,14 @@ func main() {
 	f1Scenarios := f1.Scenarios().
 		Add("one", scenarioOne).
 		Add("oneWithChaos", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosExperiments)).
+		Add("oneWithChaosBuilder", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosBuilder)).
 		Add("oneWithChaosFile", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosFromFile)).
 		Add("oneWithChaosYaml", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosFromYaml)).
 		Add("oneWithChaosWorkflow", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosWorkflow)).
+		Add("oneWithChaosWorkflowBuilder", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosWorkflowBuilder)).
 		Add("oneWithChaosWorkflowFile", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosWorkflowFile)).
-		Add("oneWithChaosWorkflowYaml", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosWorkflowYaml))
+		Add("oneWithChaosWorkflowYaml", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosWorkflowYaml)).
+		Add("oneWithChaosScheduleYaml", scenarioOne, f1Chaos.WithExperiments(scenarioOneChaosScheduleYaml))
 
 	f1Scenarios.Execute()
 }
@@ -52,6 +57,12 @@ func scenarioOneChaosExperiments(b *chaosmesh.ChaosExperimentsBuilder) {
 	})
 }
 
+func scenarioOneChaosBuilder(b *chaosmesh.ChaosExperimentsBuilder) {
+	b.Network("scenario-one-builder").
+		InNamespace("default").
+		Delay(100 * time.Millisecond)
+}
+
 func scenarioOneChaosFromFile(b *chaosmesh.ChaosExperimentsBuilder) {
 	b.WithNetworkChaosFromFile("./env/networkchaos.yaml")
 }
@@ -130,6 +141,26 @@ func scenarioOneChaosWorkflow(b *chaosmesh.ChaosExperimentsBuilder) {
 	})
 }
 
+func scenarioOneChaosWorkflowBuilder(b *chaosmesh.ChaosExperimentsBuilder) {
+	b.WithChaosWorkflowBuilder(chaosmesh.NewWorkflowBuilder("workflow-builder", "kube-system").
+		Entry(chaosmesh.Serial(
+			chaosmesh.Schedule("@every 2s", chaosmesh.PodChaosNode(v1alpha1.PodChaosSpec{
+				Action: v1alpha1.PodKillAction,
+				ContainerSelector: v1alpha1.ContainerSelector{
+					PodSelector: v1alpha1.PodSelector{
+						Mode: v1alpha1.OneMode,
+						Selector: v1alpha1.PodSelectorSpec{
+							GenericSelectorSpec: v1alpha1.GenericSelectorSpec{
+								Namespaces:     []string{"kube-system"},
+								LabelSelectors: map[string]string{"k8s-app": "kube-dns"},
+							},
+						},
+					},
+				},
+			})).Named("kill-coredns").Deadline(40 * time.Second),
+		).Deadline(5 * time.Minute)))
+}
+
 func scenarioOneChaosWorkflowFile(b *chaosmesh.ChaosExperimentsBuilder) {
 	b.WithChaosWorkflowFromFile("./env/podchaosworkflow.yaml")
 }
@@ -167,4 +198,27 @@ spec:
 `)
 }
 
+func scenarioOneChaosScheduleYaml(b *chaosmesh.ChaosExperimentsBuilder) {
+	b.WithScheduleFromYaml(`
+apiVersion: chaos-mesh.org/v1alpha1
+kind: Schedule
+metadata:
+  name: coredns-kill-schedule
+  namespace: kube-system
+spec:
+  schedule: '@every 30s'
+  concurrencyPolicy: Forbid
+  historyLimit: 1
+  type: 'PodChaos'
+  podChaos:
+    action: pod-kill
+    mode: one
+    selector:
+      namespaces:
+        - kube-system
+      labelSelectors:
+        "k8s-app": "kube-dns"
+`)
+}
+
 func strPtr(s string) *string { return &s }
@@ -0,0 +1,178 @@
+package chaosmesh
+
+import (
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	defaultInjectionTimeout = 1 * time.Minute
+	defaultPollInterval     = 2 * time.Second
+	defaultCleanupTimeout   = 1 * time.Minute
+)
+
+// ExperimentOption overrides the plugin defaults for a single experiment
+type ExperimentOption func(o *experimentOptions)
+
+// WorkflowNodeFailurePolicy decides what happens to the scenario when a node of a workflow fails
+type WorkflowNodeFailurePolicy string
+
+const (
+	// FailOnWorkflowNodeFailure fails the following iterations of the scenario
+	FailOnWorkflowNodeFailure WorkflowNodeFailurePolicy = "fail"
+	// WarnOnWorkflowNodeFailure only logs a warning
+	WarnOnWorkflowNodeFailure WorkflowNodeFailurePolicy = "warn"
+)
+
+// ReadyPredicate decides from the live object whether an experiment is ready
+type ReadyPredicate func(live *unstructured.Unstructured) (bool, error)
+
+type experimentOptions struct {
+	injectionTimeout time.Duration
+	pollInterval     time.Duration
+	cleanupTimeout   time.Duration
+	waitForRecovery  bool
+
+	// template renders files and yaml with text/template before decoding them
+	template bool
+
+	workflowNodeFailurePolicy WorkflowNodeFailurePolicy
+	waitForWorkflowCompletion bool
+
+	// the timeline of the experiment relative to the start of the load test
+	startAfter time.Duration
+	activeFor  time.Duration
+
+	// readiness overrides, when none is set the kind decides when the experiment is ready
+	readyConditions         []chaosmeshv1alpha1.ChaosConditionType
+	readyWorkflowConditions []chaosmeshv1alpha1.WorkflowConditionType
+	readyWorkflowNodes      []string
+	readyWhen               ReadyPredicate
+}
+
+func defaultExperimentOptions() experimentOptions {
+	return experimentOptions{
+		injectionTimeout: defaultInjectionTimeout,
+		pollInterval:     defaultPollInterval,
+		cleanupTimeout:   defaultCleanupTimeout,
+
+		workflowNodeFailurePolicy: FailOnWorkflowNodeFailure,
+	}
+}
+
+// WithInjectionTimeout sets how long to wait for the experiment to be injected
+func WithInjectionTimeout(timeout time.Duration) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.injectionTimeout = timeout
+	}
+}
+
+// WithPollInterval sets how often the experiment status is checked when it cannot be watched
+func WithPollInterval(interval time.Duration) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.pollInterval = interval
+	}
+}
+
+// WithCleanupTimeout sets how long to wait for the experiment to be cleaned up
+func WithCleanupTimeout(timeout time.Duration) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.cleanupTimeout = timeout
+	}
+}
+
+// WithWaitForRecovery makes the cleanup block until the experiment is removed and its targets are recovered
+func WithWaitForRecovery(wait bool) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.waitForRecovery = wait
+	}
+}
+
+// WithTemplate renders the file or yaml of the experiment with text/template, see TemplateData for the data available
+func WithTemplate(render bool) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.template = render
+	}
+}
+
+// WithWorkflowNodeFailurePolicy decides whether a failed node of the workflow fails the scenario or only logs a warning
+func WithWorkflowNodeFailurePolicy(policy WorkflowNodeFailurePolicy) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.workflowNodeFailurePolicy = policy
+	}
+}
+
+// WithWaitForWorkflowCompletion makes the cleanup of the workflow block until it is accomplished, bounded by the cleanup timeout
+func WithWaitForWorkflowCompletion(wait bool) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.waitForWorkflowCompletion = wait
+	}
+}
+
+// WithStartAfter applies the experiment once the load test has been running for the given duration, instead of before it starts
+func WithStartAfter(delay time.Duration) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.startAfter = delay
+	}
+}
+
+// WithActiveFor removes the experiment once it has been active for the given duration, while the load test keeps running
+func WithActiveFor(duration time.Duration) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.activeFor = duration
+	}
+}
+
+// WithReadyConditions waits for all the given conditions to be true instead of AllInjected, e.g. Selected or Paused
+func WithReadyConditions(conditions ...chaosmeshv1alpha1.ChaosConditionType) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.readyConditions = conditions
+	}
+}
+
+// WithReadyWorkflowConditions waits for all the given workflow conditions to be true instead of Scheduled
+func WithReadyWorkflowConditions(conditions ...chaosmeshv1alpha1.WorkflowConditionType) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.readyWorkflowConditions = conditions
+	}
+}
+
+// WithReadyWorkflowNodes waits until a node of each of the given workflow templates has started
+func WithReadyWorkflowNodes(templates ...string) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.readyWorkflowNodes = templates
+	}
+}
+
+// WithReadyWhen waits until the predicate holds for the live experiment, along with any other readiness option given
+func WithReadyWhen(predicate ReadyPredicate) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.readyWhen = predicate
+	}
+}
+
+func (o *experimentOptions) hasReadinessOverrides() bool {
+	return len(o.readyConditions) > 0 ||
+		len(o.readyWorkflowConditions) > 0 ||
+		len(o.readyWorkflowNodes) > 0 ||
+		o.readyWhen != nil
+}
+
+// validateReadiness rejects the readiness options an experiment of the given kind can never satisfy
+func (o *experimentOptions) validateReadiness(gvk schema.GroupVersionKind) error {
+	isWorkflow := gvk.Kind == chaosmeshv1alpha1.KindWorkflow
+	if len(o.readyConditions) > 0 && (isWorkflow || gvk.Kind == chaosmeshv1alpha1.KindSchedule || isPodChaos(gvk)) {
+		return errors.Errorf("ready conditions are not supported by %s experiments", gvk.Kind)
+	}
+	if (len(o.readyWorkflowConditions) > 0 || len(o.readyWorkflowNodes) > 0) && !isWorkflow {
+		return errors.Errorf("workflow readiness is not supported by %s experiments", gvk.Kind)
+	}
+	if o.workflowNodeFailurePolicy != FailOnWorkflowNodeFailure && o.workflowNodeFailurePolicy != WarnOnWorkflowNodeFailure {
+		return errors.Errorf("unknown workflow node failure policy %q", o.workflowNodeFailurePolicy)
+	}
+	return nil
+}
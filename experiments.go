@@ -1,304 +1,539 @@
 package chaosmesh
 
 import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
 	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	"github.com/samuel-form3/f1-chaos-mesh/validation"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
+// experimentsScheme knows every chaos mesh kind, it is used to infer the kind of experiments
+var experimentsScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(chaosmeshv1alpha1.AddToScheme(experimentsScheme))
+}
+
+// chaosExperiments holds the experiments in the order they were added to the builder
 type chaosExperiments struct {
-	chaos                   map[schema.GroupVersionKind][]*unstructured.Unstructured
-	chaosFromFiles          map[schema.GroupVersionKind][]string
-	chaosFromYaml           map[schema.GroupVersionKind][]string
-	chaosWorkflows          []*chaosmeshv1alpha1.Workflow
-	chaosWorkflowsFromFiles []string
-	chaosWorkflowsFromYaml  []string
+	experiments     []*chaosExperiment
+	controllerHooks []ExperimentsControllerHook
+}
+
+// chaosExperiment is a single experiment, defined either by an object, a file or a yaml document.
+// Experiments from files or yaml without a kind may hold several documents.
+type chaosExperiment struct {
+	gvk      schema.GroupVersionKind
+	obj      client.Object
+	filePath string
+	yaml     string
+	opts     *experimentOptions
+
+	// fsys is the file system filePath belongs to, files are read from the os when it is nil
+	fsys fs.FS
+
+	// builder produces the object of experiments defined with the typed builders, once they are complete
+	builder func() (interface{}, error)
+
+	// objs are the objects resolved when the experiments are built
+	objs []*experimentObject
+}
+
+func (e *chaosExperiment) describeKind() string {
+	if e.gvk.Empty() {
+		return "chaos"
+	}
+	return e.gvk.Kind
 }
 
 type ChaosExperimentsBuilder struct {
-	experiments *chaosExperiments
+	experiments  *chaosExperiments
+	defaults     experimentOptions
+	templateData TemplateData
+	errs         []error
 }
 
-func newChaosExperimentsBuilder() *ChaosExperimentsBuilder {
+// NewChaosExperimentsBuilder creates a builder with the default experiment options,
+// it allows scenario definitions to be validated without a cluster
+func NewChaosExperimentsBuilder() *ChaosExperimentsBuilder {
+	return newChaosExperimentsBuilder(defaultExperimentOptions())
+}
+
+func newChaosExperimentsBuilder(defaults experimentOptions) *ChaosExperimentsBuilder {
 	return &ChaosExperimentsBuilder{
 		experiments: &chaosExperiments{
-			chaos:                   map[schema.GroupVersionKind][]*unstructured.Unstructured{},
-			chaosFromFiles:          map[schema.GroupVersionKind][]string{},
-			chaosFromYaml:           map[schema.GroupVersionKind][]string{},
-			chaosWorkflows:          []*chaosmeshv1alpha1.Workflow{},
-			chaosWorkflowsFromFiles: []string{},
-			chaosWorkflowsFromYaml:  []string{},
+			experiments: []*chaosExperiment{},
 		},
+		defaults:     defaults,
+		templateData: newTemplateData("", "", os.Args[1:]),
 	}
 }
 
 type ChaosExperimentsConfigureFn func(b *ChaosExperimentsBuilder)
 
+// WithTemplateValues adds values available to the experiments rendered as templates as .Values
+func (b *ChaosExperimentsBuilder) WithTemplateValues(values map[string]interface{}) *ChaosExperimentsBuilder {
+	for k, v := range values {
+		b.templateData.Values[k] = v
+	}
+	return b
+}
+
+// WithControllerHook calls the hook with the controller of the scenario once its experiments are set up,
+// the controller can also be retrieved at any time with ControllerFor
+func (b *ChaosExperimentsBuilder) WithControllerHook(hook ExperimentsControllerHook) *ChaosExperimentsBuilder {
+	b.experiments.controllerHooks = append(b.experiments.controllerHooks, hook)
+	return b
+}
+
+// Any Chaos
+
+// WithChaos adds an experiment of any chaos mesh kind, the kind is inferred from the object
+func (b *ChaosExperimentsBuilder) WithChaos(obj client.Object, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	gvk, err := apiutil.GVKForObject(obj, experimentsScheme)
+	if err != nil {
+		return b.addError(err, "could not infer the kind of %T", obj)
+	}
+
+	switch o := obj.(type) {
+	case *chaosmeshv1alpha1.Workflow:
+		return b.WithChaosWorkflow(o, opts...)
+	case *chaosmeshv1alpha1.Schedule:
+		return b.WithSchedule(o, opts...)
+	case *unstructured.Unstructured:
+		return b.add(&chaosExperiment{gvk: gvk, obj: o}, opts)
+	default:
+		return b.withChaos(gvk, obj, opts...)
+	}
+}
+
+// WithChaosFromFile adds the experiments of a yaml file, which may hold several
+// documents of any chaos mesh kind separated by "---"
+func (b *ChaosExperimentsBuilder) WithChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(schema.GroupVersionKind{}, filePath, opts...)
+}
+
+// WithChaosFromYaml adds the experiments of a yaml, which may hold several
+// documents of any chaos mesh kind separated by "---"
+func (b *ChaosExperimentsBuilder) WithChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(schema.GroupVersionKind{}, yaml, opts...)
+}
+
+// WithExperimentsFromDir adds the experiments of every yaml and json file of a directory, ordered by file name.
+// The files may hold several documents of any chaos mesh kind, subdirectories are ignored.
+func (b *ChaosExperimentsBuilder) WithExperimentsFromDir(dir string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return b.addError(err, "could not read directory %s", dir)
+	}
+
+	found := false
+	for _, entry := range entries {
+		if entry.IsDir() || !isManifestFile(entry.Name()) {
+			continue
+		}
+		found = true
+		b.add(&chaosExperiment{filePath: filepath.Join(dir, entry.Name())}, opts)
+	}
+	if !found {
+		return b.addError(errors.New("no yaml or json files"), "directory %s", dir)
+	}
+	return b
+}
+
+// WithExperimentsFromFS adds the experiments of every yaml and json file of the file system matching the pattern,
+// ordered by file name. It works with embed.FS, so that scenario binaries do not depend on their working directory.
+func (b *ChaosExperimentsBuilder) WithExperimentsFromFS(fsys fs.FS, pattern string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	matches, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return b.addError(err, "invalid pattern %s", pattern)
+	}
+	sort.Strings(matches)
+
+	// the files are added all together, once every match has been checked
+	var files []string
+	var errs []error
+	for _, name := range matches {
+		info, err := fs.Stat(fsys, name)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not read file %s", name))
+			continue
+		}
+		if info.IsDir() || !isManifestFile(name) {
+			continue
+		}
+		files = append(files, name)
+	}
+	if len(errs) > 0 {
+		return b.addError(utilerrors.NewAggregate(errs), "pattern %s", pattern)
+	}
+	if len(files) == 0 {
+		return b.addError(errors.New("no yaml or json files"), "pattern %s", pattern)
+	}
+
+	for _, name := range files {
+		b.add(&chaosExperiment{filePath: name, fsys: fsys}, opts)
+	}
+	return b
+}
+
+func isManifestFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
 // AWS CHAOS
 
-func (b *ChaosExperimentsBuilder) WithAWSChaos(c *chaosmeshv1alpha1.AWSChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("AWSChaos"), c)
+func (b *ChaosExperimentsBuilder) WithAWSChaos(c *chaosmeshv1alpha1.AWSChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("AWSChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithAWSChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("AWSChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithAWSChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("AWSChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithAWSChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("AWSChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithAWSChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("AWSChaos"), yaml, opts...)
 }
 
 // DNS Chaos
 
-func (b *ChaosExperimentsBuilder) WithDNSChaos(c *chaosmeshv1alpha1.DNSChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("DNSChaos"), c)
+func (b *ChaosExperimentsBuilder) WithDNSChaos(c *chaosmeshv1alpha1.DNSChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("DNSChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithDNSChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("DNSChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithDNSChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("DNSChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithDNSChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("DNSChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithDNSChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("DNSChaos"), yaml, opts...)
 }
 
 // GCP Chaos
 
-func (b *ChaosExperimentsBuilder) WithGCPChaos(c *chaosmeshv1alpha1.GCPChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("GCPChaos"), c)
+func (b *ChaosExperimentsBuilder) WithGCPChaos(c *chaosmeshv1alpha1.GCPChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("GCPChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithGCPChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("GCPChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithGCPChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("GCPChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithGCPChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("GCPChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithGCPChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("GCPChaos"), yaml, opts...)
 }
 
 // HTTP Chaos
 
-func (b *ChaosExperimentsBuilder) WithHTTPChaos(c *chaosmeshv1alpha1.HTTPChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("HTTPChaos"), c)
+func (b *ChaosExperimentsBuilder) WithHTTPChaos(c *chaosmeshv1alpha1.HTTPChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("HTTPChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithHTTPChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("HTTPChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithHTTPChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("HTTPChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithHTTPChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("HTTPChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithHTTPChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("HTTPChaos"), yaml, opts...)
 }
 
 // IO Chaos
 
-func (b *ChaosExperimentsBuilder) WithIOChaos(c *chaosmeshv1alpha1.IOChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("IOChaos"), c)
+func (b *ChaosExperimentsBuilder) WithIOChaos(c *chaosmeshv1alpha1.IOChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("IOChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithIOChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("IOChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithIOChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("IOChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithIOChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("IOChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithIOChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("IOChaos"), yaml, opts...)
 }
 
 // JVM Chaos
 
-func (b *ChaosExperimentsBuilder) WithJVMChaos(c *chaosmeshv1alpha1.JVMChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("JVMChaos"), c)
+func (b *ChaosExperimentsBuilder) WithJVMChaos(c *chaosmeshv1alpha1.JVMChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("JVMChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithJVMChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("JVMChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithJVMChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("JVMChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithJVMChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("JVMChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithJVMChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("JVMChaos"), yaml, opts...)
 }
 
 // Kernel Chaos
 
-func (b *ChaosExperimentsBuilder) WithKernelChaos(c *chaosmeshv1alpha1.KernelChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("KernelChaos"), c)
+func (b *ChaosExperimentsBuilder) WithKernelChaos(c *chaosmeshv1alpha1.KernelChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("KernelChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithKernelChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("KernelChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithKernelChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("KernelChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithKernelChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("KernelChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithKernelChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("KernelChaos"), yaml, opts...)
 }
 
 // Network Chaos
 
-func (b *ChaosExperimentsBuilder) WithNetworkChaos(c *chaosmeshv1alpha1.NetworkChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"), c)
+func (b *ChaosExperimentsBuilder) WithNetworkChaos(c *chaosmeshv1alpha1.NetworkChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithNetworkChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithNetworkChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithNetworkChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithNetworkChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"), yaml, opts...)
 }
 
 // Physical Machine Chaos
 
-func (b *ChaosExperimentsBuilder) WithPhysicalMachineChaos(c *chaosmeshv1alpha1.PhysicalMachine) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PhysicalMachine"), c)
+func (b *ChaosExperimentsBuilder) WithPhysicalMachineChaos(c *chaosmeshv1alpha1.PhysicalMachine, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PhysicalMachine"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPhysicalMachineFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PhysicalMachine"), filePath)
+func (b *ChaosExperimentsBuilder) WithPhysicalMachineFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PhysicalMachine"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPhysicalMachineFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PhysicalMachine"), yaml)
+func (b *ChaosExperimentsBuilder) WithPhysicalMachineFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PhysicalMachine"), yaml, opts...)
 }
 
 // Pod Chaos
 
-func (b *ChaosExperimentsBuilder) WithPodChaos(c *chaosmeshv1alpha1.PodChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos"), c)
+func (b *ChaosExperimentsBuilder) WithPodChaos(c *chaosmeshv1alpha1.PodChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPodChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithPodChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPodChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithPodChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PodChaos"), yaml, opts...)
 }
 
 // Pod HTTP Chaos
 
-func (b *ChaosExperimentsBuilder) WithPodHTTPChaos(c *chaosmeshv1alpha1.PodHttpChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PodHttpChaos"), c)
+func (b *ChaosExperimentsBuilder) WithPodHTTPChaos(c *chaosmeshv1alpha1.PodHttpChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PodHttpChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPodHttpChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PodHttpChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithPodHttpChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PodHttpChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPodHttpChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PodHttpChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithPodHttpChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PodHttpChaos"), yaml, opts...)
 }
 
 // Pod IO Chaos
 
-func (b *ChaosExperimentsBuilder) WithPodIOChaos(c *chaosmeshv1alpha1.PodIOChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PodIOChaos"), c)
+func (b *ChaosExperimentsBuilder) WithPodIOChaos(c *chaosmeshv1alpha1.PodIOChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PodIOChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPodIOChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PodIOChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithPodIOChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PodIOChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPodIOChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PodIOChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithPodIOChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PodIOChaos"), yaml, opts...)
 }
 
 // Pod Network Chaos
 
-func (b *ChaosExperimentsBuilder) WithPodNetworkChaos(c *chaosmeshv1alpha1.PodNetworkChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PodNetworkChaos"), c)
+func (b *ChaosExperimentsBuilder) WithPodNetworkChaos(c *chaosmeshv1alpha1.PodNetworkChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("PodNetworkChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPodNetworkChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PodNetworkChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithPodNetworkChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("PodNetworkChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithPodNetworkChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PodNetworkChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithPodNetworkChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("PodNetworkChaos"), yaml, opts...)
 }
 
 // Stress Chaos
 
-func (b *ChaosExperimentsBuilder) WithStressChaos(c *chaosmeshv1alpha1.StressChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("StressChaos"), c)
+func (b *ChaosExperimentsBuilder) WithStressChaos(c *chaosmeshv1alpha1.StressChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("StressChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithStressChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("StressChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithStressChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("StressChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithStressChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("StressChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithStressChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("StressChaos"), yaml, opts...)
 }
 
 // Time Chaos
 
-func (b *ChaosExperimentsBuilder) WithTimeChaos(c *chaosmeshv1alpha1.TimeChaos) *ChaosExperimentsBuilder {
-	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("TimeChaos"), c)
+func (b *ChaosExperimentsBuilder) WithTimeChaos(c *chaosmeshv1alpha1.TimeChaos, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaos(chaosmeshv1alpha1.GroupVersion.WithKind("TimeChaos"), c, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithTimeChaosFromFile(filePath string) *ChaosExperimentsBuilder {
-	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("TimeChaos"), filePath)
+func (b *ChaosExperimentsBuilder) WithTimeChaosFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("TimeChaos"), filePath, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithTimeChaosFromYaml(yaml string) *ChaosExperimentsBuilder {
-	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("TimeChaos"), yaml)
+func (b *ChaosExperimentsBuilder) WithTimeChaosFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("TimeChaos"), yaml, opts...)
 }
 
 // Chaos Workflow
 
-func (b *ChaosExperimentsBuilder) WithChaosWorkflow(c *chaosmeshv1alpha1.Workflow) *ChaosExperimentsBuilder {
-	b.experiments.chaosWorkflows = append(b.experiments.chaosWorkflows, c)
-	return b
+func (b *ChaosExperimentsBuilder) WithChaosWorkflow(c *chaosmeshv1alpha1.Workflow, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.add(&chaosExperiment{gvk: chaosmeshv1alpha1.GroupVersion.WithKind("Workflow"), obj: c}, opts)
 }
 
-func (b *ChaosExperimentsBuilder) WithChaosWorkflowFromFile(filePath string) *ChaosExperimentsBuilder {
-	b.experiments.chaosWorkflowsFromFiles = append(b.experiments.chaosWorkflowsFromFiles, filePath)
-	return b
+// WithChaosWorkflowBuilder adds the workflow of the builder, problems building it are reported with the other experiments
+func (b *ChaosExperimentsBuilder) WithChaosWorkflowBuilder(wb *WorkflowBuilder, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	wf, err := wb.Build()
+	if err != nil {
+		return b.addError(err, "could not build workflow")
+	}
+	return b.WithChaosWorkflow(wf, opts...)
 }
 
-func (b *ChaosExperimentsBuilder) WithChaosWorkflowFromYaml(yaml string) *ChaosExperimentsBuilder {
-	b.experiments.chaosWorkflowsFromYaml = append(b.experiments.chaosWorkflowsFromYaml, yaml)
-	return b
+func (b *ChaosExperimentsBuilder) WithChaosWorkflowFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("Workflow"), filePath, opts...)
+}
+
+func (b *ChaosExperimentsBuilder) WithChaosWorkflowFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("Workflow"), yaml, opts...)
+}
+
+// Chaos Schedule
+
+func (b *ChaosExperimentsBuilder) WithSchedule(c *chaosmeshv1alpha1.Schedule, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.add(&chaosExperiment{gvk: chaosmeshv1alpha1.GroupVersion.WithKind("Schedule"), obj: c}, opts)
+}
+
+func (b *ChaosExperimentsBuilder) WithScheduleFromFile(filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromFile(chaosmeshv1alpha1.GroupVersion.WithKind("Schedule"), filePath, opts...)
+}
+
+func (b *ChaosExperimentsBuilder) WithScheduleFromYaml(yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.withChaosFromYaml(chaosmeshv1alpha1.GroupVersion.WithKind("Schedule"), yaml, opts...)
 }
 
 // Chaos
 
-func (b *ChaosExperimentsBuilder) withChaos(gvk schema.GroupVersionKind, c interface{}) *ChaosExperimentsBuilder {
-	_, ok := b.experiments.chaos[gvk]
-	if !ok {
-		b.experiments.chaos[gvk] = []*unstructured.Unstructured{}
+func (b *ChaosExperimentsBuilder) withChaos(gvk schema.GroupVersionKind, c interface{}, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	uc, err := toChaosObject(gvk, c)
+	if err != nil {
+		return b.addError(err, "could not convert %s", gvk.Kind)
 	}
 
-	obj, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(c)
+	return b.add(&chaosExperiment{gvk: gvk, obj: uc}, opts)
+}
+
+// toChaosObject converts a typed chaos to the unstructured object experiments are created from
+func toChaosObject(gvk schema.GroupVersionKind, c interface{}) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(c)
+	if err != nil {
+		return nil, err
+	}
 	uc := &unstructured.Unstructured{Object: obj}
 	uc.SetGroupVersionKind(gvk)
+	return uc, nil
+}
 
-	b.experiments.chaos[gvk] = append(b.experiments.chaos[gvk], uc)
+func (b *ChaosExperimentsBuilder) withChaosFromFile(gvk schema.GroupVersionKind, filePath string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.add(&chaosExperiment{gvk: gvk, filePath: filePath}, opts)
+}
 
-	return b
+func (b *ChaosExperimentsBuilder) withChaosFromYaml(gvk schema.GroupVersionKind, yaml string, opts ...ExperimentOption) *ChaosExperimentsBuilder {
+	return b.add(&chaosExperiment{gvk: gvk, yaml: yaml}, opts)
 }
 
-func (b *ChaosExperimentsBuilder) withChaosFromFile(gvk schema.GroupVersionKind, filePath string) *ChaosExperimentsBuilder {
-	_, ok := b.experiments.chaosFromFiles[gvk]
-	if !ok {
-		b.experiments.chaosFromFiles[gvk] = []string{filePath}
-	} else {
-		b.experiments.chaosFromFiles[gvk] = append(b.experiments.chaosFromFiles[gvk], filePath)
-	}
+func (b *ChaosExperimentsBuilder) add(exp *chaosExperiment, opts []ExperimentOption) *ChaosExperimentsBuilder {
+	exp.opts = b.experimentOptions(opts)
+	b.experiments.experiments = append(b.experiments.experiments, exp)
 	return b
 }
 
-func (b *ChaosExperimentsBuilder) withChaosFromYaml(gvk schema.GroupVersionKind, yaml string) *ChaosExperimentsBuilder {
-	_, ok := b.experiments.chaosFromYaml[gvk]
-	if !ok {
-		b.experiments.chaosFromYaml[gvk] = []string{yaml}
-	} else {
-		b.experiments.chaosFromYaml[gvk] = append(b.experiments.chaosFromYaml[gvk], yaml)
+// experimentOptions applies the experiment overrides on top of the plugin defaults
+func (b *ChaosExperimentsBuilder) experimentOptions(opts []ExperimentOption) *experimentOptions {
+	o := b.defaults
+	for _, opt := range opts {
+		opt(&o)
 	}
+	return &o
+}
+
+// addError records a problem with the experiment being added, it is reported when the experiments are built
+func (b *ChaosExperimentsBuilder) addError(err error, format string, args ...interface{}) *ChaosExperimentsBuilder {
+	err = errors.Wrapf(err, format, args...)
+	b.errs = append(b.errs, errors.Wrapf(err, "experiment #%d", len(b.experiments.experiments)+1))
 	return b
 }
 
-func (b *ChaosExperimentsBuilder) build() *chaosExperiments {
-	return b.experiments
+// Validate loads every experiment and validates it locally with the rules of the chaos mesh webhooks,
+// all the problems found are returned as an aggregate error
+func (b *ChaosExperimentsBuilder) Validate() error {
+	_, err := b.build()
+	return err
+}
+
+// build resolves the objects of every experiment, reporting all the problems found in the experiments
+func (b *ChaosExperimentsBuilder) build() (*chaosExperiments, error) {
+	errs := b.errs
+	for i, exp := range b.experiments.experiments {
+		objs, err := loadExperiment(exp, b.templateData)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "%s experiment #%d", exp.describeKind(), i+1))
+			continue
+		}
+
+		for _, o := range objs {
+			err := validateExperimentObject(o)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "%s experiment #%d", exp.describeKind(), i+1))
+				continue
+			}
+
+			err = validation.Validate(o.obj)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "%s experiment #%d %s", exp.describeKind(), i+1, o.obj.GetName()))
+			}
+
+			err = exp.opts.validateReadiness(o.gvk)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "%s experiment #%d %s", exp.describeKind(), i+1, o.obj.GetName()))
+			}
+		}
+		exp.objs = objs
+	}
+
+	return b.experiments, utilerrors.NewAggregate(errs)
 }
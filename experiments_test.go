@@ -0,0 +1,82 @@
+package chaosmesh
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+const networkChaosManifest = `apiVersion: chaos-mesh.org/v1alpha1
+kind: NetworkChaos
+metadata:
+  name: network-delay
+  namespace: kube-system
+spec:
+  action: delay
+  mode: all
+  selector:
+    namespaces:
+      - kube-system
+  delay:
+    latency: '10ms'
+`
+
+func TestExperimentsFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"chaos/b.yaml":    {Data: []byte(networkChaosManifest)},
+		"chaos/a.yml":     {Data: []byte(networkChaosManifest)},
+		"chaos/README.md": {Data: []byte("manifests")},
+	}
+	b := NewChaosExperimentsBuilder()
+
+	b.WithExperimentsFromFS(fsys, "chaos/*")
+
+	require.NoError(t, b.Validate())
+	require.Len(t, b.experiments.experiments, 2)
+	require.Equal(t, "chaos/a.yml", b.experiments.experiments[0].filePath)
+	require.Equal(t, "chaos/b.yaml", b.experiments.experiments[1].filePath)
+}
+
+func TestExperimentsFromFSAreAddedTogether(t *testing.T) {
+	fsys := &unreadableFS{
+		MapFS: fstest.MapFS{
+			"chaos/a.yaml": {Data: []byte(networkChaosManifest)},
+			"chaos/b.yaml": {Data: []byte(networkChaosManifest)},
+			"chaos/c.yaml": {Data: []byte(networkChaosManifest)},
+			"chaos/d.yaml": {Data: []byte(networkChaosManifest)},
+		},
+		unreadable: map[string]bool{"chaos/b.yaml": true, "chaos/d.yaml": true},
+	}
+	b := NewChaosExperimentsBuilder()
+
+	b.WithExperimentsFromFS(fsys, "chaos/*")
+
+	require.Empty(t, b.experiments.experiments)
+	require.EqualError(t, b.Validate(), "experiment #1: pattern chaos/*: "+
+		"[could not read file chaos/b.yaml: permission denied, could not read file chaos/d.yaml: permission denied]")
+}
+
+func TestExperimentsFromFSWithoutManifests(t *testing.T) {
+	b := NewChaosExperimentsBuilder()
+
+	b.WithExperimentsFromFS(fstest.MapFS{"chaos/README.md": {Data: []byte("manifests")}}, "chaos/*")
+
+	require.Empty(t, b.experiments.experiments)
+	require.EqualError(t, b.Validate(), "experiment #1: pattern chaos/*: no yaml or json files")
+}
+
+// unreadableFS fails to stat some of its files
+type unreadableFS struct {
+	fstest.MapFS
+	unreadable map[string]bool
+}
+
+func (u *unreadableFS) Stat(name string) (fs.FileInfo, error) {
+	if u.unreadable[name] {
+		return nil, errors.New("permission denied")
+	}
+	return u.MapFS.Stat(name)
+}
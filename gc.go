@@ -0,0 +1,76 @@
+package chaosmesh
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GarbageCollect deletes the experiments left behind by f1 runs whose TTL has expired
+// or whose process is no longer running on this host. It returns the deleted experiments.
+func (cp *ChaosPlugin) GarbageCollect(ctx context.Context) ([]string, error) {
+	if cp.initErr != nil {
+		return nil, cp.initErr
+	}
+
+	now := time.Now()
+	collected := []string{}
+	var errs []error
+
+	for _, kind := range managedKinds() {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind(kind + "List"))
+		err := cp.kubeCli.List(ctx, list, client.MatchingLabels{LabelManagedBy: pluginName})
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not list %s experiments", kind))
+			continue
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if !cp.run.isOrphaned(obj, now) {
+				continue
+			}
+
+			expFriendlyName := generateExperimentFriendlyName(kind, obj.GetNamespace(), obj.GetName())
+			err := cp.kubeCli.Delete(ctx, obj, uidPrecondition(obj))
+			if err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, errors.Wrapf(err, "could not garbage collect chaos experiment %s", expFriendlyName))
+				continue
+			}
+
+			if kind == chaosmeshv1alpha1.KindSchedule {
+				scheduleType, _, _ := unstructured.NestedString(obj.Object, "spec", "type")
+				err = deleteScheduleChildren(ctx, cp.kubeCli, obj.GetNamespace(), obj.GetName(), scheduleType)
+				if err != nil {
+					errs = append(errs, errors.Wrapf(err, "could not garbage collect experiments spawned by %s", expFriendlyName))
+				}
+			}
+
+			collected = append(collected, expFriendlyName)
+		}
+	}
+
+	return collected, utilerrors.NewAggregate(errs)
+}
+
+// managedKinds returns every chaos mesh kind the plugin can create
+func managedKinds() []string {
+	kinds := []string{"PhysicalMachine", "PodHttpChaos", "PodIOChaos", "PodNetworkChaos"}
+	for kind := range chaosmeshv1alpha1.AllKindsIncludeScheduleAndWorkflow() {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,146 @@
+package chaosmesh
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultInterruptCleanupTimeout = 30 * time.Second
+
+// liveExperiments is the process-wide registry of the experiments currently applied in the cluster,
+// they are deleted when the process is interrupted independently of f1's own teardown
+var liveExperiments = &experimentsRegistry{}
+
+type experimentsRegistry struct {
+	mu          sync.Mutex
+	experiments []*liveExperiment
+	installOnce sync.Once
+}
+
+type liveExperiment struct {
+	kubeCli client.Client
+	gvk     schema.GroupVersionKind
+	obj     client.Object
+	timeout time.Duration
+}
+
+// add registers a copy of an experiment along with the interrupt cleanup timeout of its plugin,
+// the first registration installs the signal handler
+func (r *experimentsRegistry) add(kubeCli client.Client, gvk schema.GroupVersionKind, obj client.Object, timeout time.Duration) {
+	r.installOnce.Do(func() {
+		signals := make(chan os.Signal, 2)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go r.handleSignals(signals)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.experiments = append(r.experiments, &liveExperiment{
+		kubeCli: kubeCli,
+		gvk:     gvk,
+		obj:     obj.DeepCopyObject().(client.Object),
+		timeout: timeout,
+	})
+}
+
+func (r *experimentsRegistry) remove(obj client.Object) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, exp := range r.experiments {
+		if exp.obj.GetUID() == obj.GetUID() {
+			r.experiments = append(r.experiments[:i], r.experiments[i+1:]...)
+			return
+		}
+	}
+}
+
+// handleSignals deletes the live experiments on the first interrupt, then restores the default signal
+// behaviour and raises the signal again so that the process terminates. A second interrupt received
+// while deleting terminates the process at once.
+func (r *experimentsRegistry) handleSignals(signals chan os.Signal) {
+	sig := <-signals
+	log.Warn("Interrupted, deleting live chaos experiments")
+
+	done := make(chan struct{})
+	go func() {
+		r.cleanup()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		signal.Stop(signals)
+		log.Warn("Live chaos experiments deleted, exiting")
+		raise(sig)
+	case sig = <-signals:
+		log.Warn("Interrupted again, exiting without waiting for live chaos experiments to be deleted")
+		os.Exit(exitCode(sig))
+	}
+}
+
+// raise sends the signal to the process again, exiting when it cannot be sent
+func raise(sig os.Signal) {
+	s, ok := sig.(syscall.Signal)
+	if !ok || syscall.Kill(os.Getpid(), s) != nil {
+		os.Exit(exitCode(sig))
+	}
+}
+
+func exitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
+
+// cleanup deletes the live experiments in the reverse order they were created, all of them within
+// a single deadline given by the longest interrupt cleanup timeout of the plugins that created them
+func (r *experimentsRegistry) cleanup() {
+	r.mu.Lock()
+	experiments := r.experiments
+	r.experiments = nil
+	r.mu.Unlock()
+
+	var timeout time.Duration
+	for _, exp := range experiments {
+		if exp.timeout > timeout {
+			timeout = exp.timeout
+		}
+	}
+	log.Infof("Deleting %d live chaos experiments (timeout: %s)", len(experiments), timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for i := len(experiments) - 1; i >= 0; i-- {
+		exp := experiments[i]
+		expFriendlyName := generateExperimentFriendlyName(exp.gvk.Kind, exp.obj.GetNamespace(), exp.obj.GetName())
+
+		err := exp.delete(ctx)
+		if err != nil {
+			log.Errorf("Could not delete chaos experiment %s, err: %s", expFriendlyName, err)
+			continue
+		}
+		log.Infof("Deleted chaos experiment %s", expFriendlyName)
+	}
+}
+
+func (e *liveExperiment) delete(ctx context.Context) error {
+	err := client.IgnoreNotFound(e.kubeCli.Delete(ctx, e.obj, uidPrecondition(e.obj)))
+	if err != nil {
+		return err
+	}
+	if sc, ok := e.obj.(*chaosmeshv1alpha1.Schedule); ok {
+		return deleteScheduleChildren(ctx, e.kubeCli, sc.GetNamespace(), sc.GetName(), string(sc.Spec.Type))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package chaosmesh
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRegistryAddAndRemove(t *testing.T) {
+	r := newTestRegistry()
+	kubeCli := fake.NewClientBuilder().WithScheme(experimentsScheme).Build()
+	first, second := networkChaosObject("first"), networkChaosObject("second")
+
+	r.add(kubeCli, first.GroupVersionKind(), first, time.Second)
+	r.add(kubeCli, second.GroupVersionKind(), second, time.Second)
+	r.remove(networkChaosObject("first"))
+
+	require.Len(t, r.experiments, 1)
+	require.Equal(t, "second", r.experiments[0].obj.GetName())
+	require.NotSame(t, second, r.experiments[0].obj, "the registry keeps a copy of the experiment")
+}
+
+func TestRegistryCleanupDeletesInReverseOrder(t *testing.T) {
+	r := newTestRegistry()
+	objs := []*unstructured.Unstructured{networkChaosObject("first"), networkChaosObject("second"), networkChaosObject("third")}
+	kubeCli := &recordingClient{Client: fake.NewClientBuilder().WithScheme(experimentsScheme).Build()}
+	for _, obj := range objs {
+		require.NoError(t, kubeCli.Create(context.Background(), obj))
+		r.add(kubeCli, obj.GroupVersionKind(), obj, time.Second)
+	}
+
+	r.cleanup()
+
+	require.Equal(t, []string{"third", "second", "first"}, kubeCli.deleted)
+	require.Empty(t, r.experiments)
+	for _, obj := range objs {
+		err := kubeCli.Get(context.Background(), types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, obj.DeepCopy())
+		require.True(t, apierrors.IsNotFound(err), "experiment %s was not deleted", obj.GetName())
+	}
+}
+
+func TestRegistryCleanupHasASingleDeadline(t *testing.T) {
+	r := newTestRegistry()
+	kubeCli := &recordingClient{Client: fake.NewClientBuilder().WithScheme(experimentsScheme).Build(), block: true}
+	r.add(kubeCli, networkChaosObject("first").GroupVersionKind(), networkChaosObject("first"), 100*time.Millisecond)
+	r.add(kubeCli, networkChaosObject("second").GroupVersionKind(), networkChaosObject("second"), 200*time.Millisecond)
+	r.add(kubeCli, networkChaosObject("third").GroupVersionKind(), networkChaosObject("third"), 100*time.Millisecond)
+
+	start := time.Now()
+	r.cleanup()
+
+	require.Len(t, kubeCli.deadlines, 3)
+	for _, deadline := range kubeCli.deadlines {
+		require.Equal(t, kubeCli.deadlines[0], deadline)
+	}
+	require.WithinDuration(t, start.Add(200*time.Millisecond), kubeCli.deadlines[0], 50*time.Millisecond)
+	require.Less(t, time.Since(start), 400*time.Millisecond)
+}
+
+// newTestRegistry returns a registry that does not install a signal handler
+func newTestRegistry() *experimentsRegistry {
+	r := &experimentsRegistry{}
+	r.installOnce.Do(func() {})
+	return r
+}
+
+func networkChaosObject(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(chaosmeshv1alpha1.GroupVersion.WithKind("NetworkChaos"))
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetUID(types.UID(name))
+	return obj
+}
+
+// recordingClient records the deletions, blocking them until their context is done when asked to
+type recordingClient struct {
+	client.Client
+	block bool
+
+	mu        sync.Mutex
+	deleted   []string
+	deadlines []time.Time
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.mu.Lock()
+	c.deleted = append(c.deleted, obj.GetName())
+	deadline, _ := ctx.Deadline()
+	c.deadlines = append(c.deadlines, deadline)
+	c.mu.Unlock()
+
+	if c.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
@@ -0,0 +1,153 @@
+package chaosmesh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	yamlUtil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// experimentObject is an object to create in the cluster along with its kind,
+// typed objects lose their kind once they go through the client
+type experimentObject struct {
+	gvk schema.GroupVersionKind
+	obj client.Object
+}
+
+// loadExperiment returns the objects of the experiment, decoding them from its file or yaml when needed.
+// Files and yaml are rendered with the template data first when the experiment is a template.
+func loadExperiment(exp *chaosExperiment, data TemplateData) ([]*experimentObject, error) {
+	if exp.obj != nil {
+		return []*experimentObject{{gvk: exp.gvk, obj: exp.obj}}, nil
+	}
+
+	if exp.builder != nil {
+		c, err := exp.builder()
+		if err != nil {
+			return nil, err
+		}
+		obj, err := toChaosObject(exp.gvk, c)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not convert %s", exp.gvk.Kind)
+		}
+		return []*experimentObject{{gvk: exp.gvk, obj: obj}}, nil
+	}
+
+	manifest, source := []byte(exp.yaml), "yaml"
+	if exp.filePath != "" {
+		content, err := readExperimentFile(exp)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error opening file %s", exp.filePath)
+		}
+		manifest, source = content, exp.filePath
+	}
+
+	if exp.opts.template {
+		rendered, err := renderTemplate(source, manifest, data)
+		if err != nil {
+			return nil, err
+		}
+		manifest = rendered
+	}
+
+	objs, err := decodeExperiment(exp.gvk, bytes.NewReader(manifest))
+	if err != nil && exp.filePath != "" {
+		return nil, errors.Wrapf(err, "error decoding yaml from file %s", exp.filePath)
+	}
+
+	return objs, err
+}
+
+func readExperimentFile(exp *chaosExperiment) ([]byte, error) {
+	if exp.fsys != nil {
+		return fs.ReadFile(exp.fsys, exp.filePath)
+	}
+	return os.ReadFile(exp.filePath)
+}
+
+// decodeExperiment decodes a single document of the given kind, experiments added
+// without a kind may hold several yaml documents, each one with its own kind
+func decodeExperiment(gvk schema.GroupVersionKind, r io.Reader) ([]*experimentObject, error) {
+	objs, err := decodeExperiments(r, gvk)
+	if err != nil || gvk.Empty() {
+		return objs, err
+	}
+
+	if len(objs) != 1 {
+		return nil, fmt.Errorf("expected a single %s document, found %d", gvk.Kind, len(objs))
+	}
+	if objs[0].gvk != gvk {
+		return nil, fmt.Errorf("manifest kind %s does not match expected kind %s", objs[0].gvk.Kind, gvk.Kind)
+	}
+
+	return objs, nil
+}
+
+// decodeExperiments decodes every document of a multi-document yaml, inferring the kind of each one.
+// Documents without apiVersion and kind get the default kind, if any.
+func decodeExperiments(r io.Reader, defaultGVK schema.GroupVersionKind) ([]*experimentObject, error) {
+	objs := []*experimentObject{}
+	decoder := yamlUtil.NewYAMLOrJSONDecoder(r, 100)
+	for {
+		u := &unstructured.Unstructured{}
+		err := decoder.Decode(&u.Object)
+		if err == io.EOF {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+
+		if u.GetAPIVersion() == "" && u.GetKind() == "" && !defaultGVK.Empty() {
+			u.SetGroupVersionKind(defaultGVK)
+		}
+
+		gvk := u.GroupVersionKind()
+		if gvk.Group != chaosmeshv1alpha1.GroupVersion.Group || gvk.Kind == "" {
+			return nil, fmt.Errorf("%s %s is not a chaos mesh experiment", u.GetAPIVersion(), u.GetKind())
+		}
+
+		obj := newExperimentObject(gvk)
+		if _, ok := obj.(*unstructured.Unstructured); ok {
+			obj = u
+		} else if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+			return nil, err
+		}
+
+		objs = append(objs, &experimentObject{gvk: gvk, obj: obj})
+	}
+}
+
+func newExperimentObject(gvk schema.GroupVersionKind) client.Object {
+	switch gvk.Kind {
+	case "Workflow":
+		return &chaosmeshv1alpha1.Workflow{}
+	case "Schedule":
+		return &chaosmeshv1alpha1.Schedule{}
+	default:
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		return obj
+	}
+}
+
+// validateExperimentObject checks the object can be created in the cluster,
+// experiments without a namespace are created in the namespace of the plugin
+func validateExperimentObject(o *experimentObject) error {
+	if o.obj.GetName() == "" {
+		return fmt.Errorf("%s: missing name", generateExperimentFriendlyName(o.gvk.Kind, o.obj.GetNamespace(), o.obj.GetName()))
+	}
+	return nil
+}
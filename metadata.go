@@ -0,0 +1,106 @@
+package chaosmesh
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	pluginName = "f1-chaos-mesh"
+
+	defaultRunTTL = 24 * time.Hour
+
+	LabelManagedBy = "f1-chaos-mesh/managed-by"
+	LabelRunID     = "f1-chaos-mesh/run-id"
+
+	AnnotationScenario  = "f1-chaos-mesh/scenario"
+	AnnotationHost      = "f1-chaos-mesh/host"
+	AnnotationPID       = "f1-chaos-mesh/pid"
+	AnnotationStartTime = "f1-chaos-mesh/start-time"
+	AnnotationTTL       = "f1-chaos-mesh/ttl"
+)
+
+// runMetadata identifies the f1 run that created an experiment, so that the experiments
+// left behind by a run that did not clean up can be garbage collected
+type runMetadata struct {
+	runID     string
+	host      string
+	pid       int
+	startTime time.Time
+	ttl       time.Duration
+}
+
+func newRunMetadata(ttl time.Duration) *runMetadata {
+	host, _ := os.Hostname()
+	return &runMetadata{
+		runID:     rand.String(8),
+		host:      host,
+		pid:       os.Getpid(),
+		startTime: time.Now().UTC(),
+		ttl:       ttl,
+	}
+}
+
+// stamp labels and annotates the object with the run metadata
+func (m *runMetadata) stamp(obj client.Object, scenario string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[LabelManagedBy] = pluginName
+	labels[LabelRunID] = m.runID
+	obj.SetLabels(labels)
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationScenario] = scenario
+	annotations[AnnotationHost] = m.host
+	annotations[AnnotationPID] = strconv.Itoa(m.pid)
+	annotations[AnnotationStartTime] = m.startTime.Format(time.RFC3339)
+	annotations[AnnotationTTL] = m.ttl.String()
+	obj.SetAnnotations(annotations)
+}
+
+// isOrphaned reports whether the object was left behind by another run that either
+// outlived its TTL or whose process is no longer running on this host
+func (m *runMetadata) isOrphaned(obj client.Object, now time.Time) bool {
+	if obj.GetLabels()[LabelRunID] == m.runID {
+		return false
+	}
+
+	annotations := obj.GetAnnotations()
+	startTime, startErr := time.Parse(time.RFC3339, annotations[AnnotationStartTime])
+	ttl, ttlErr := time.ParseDuration(annotations[AnnotationTTL])
+	if startErr == nil && ttlErr == nil && now.After(startTime.Add(ttl)) {
+		return true
+	}
+
+	if annotations[AnnotationHost] != m.host {
+		return false
+	}
+
+	pid, err := strconv.Atoi(annotations[AnnotationPID])
+	if err != nil {
+		return false
+	}
+
+	return !processRunning(pid)
+}
+
+func processRunning(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,58 @@
+package chaosmesh
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestIsOrphaned(t *testing.T) {
+	now := time.Now().UTC()
+	run := &runMetadata{runID: "current", host: "this-host", pid: 1, startTime: now, ttl: time.Hour}
+
+	tests := []struct {
+		name     string
+		runID    string
+		host     string
+		pid      int
+		started  time.Time
+		ttl      time.Duration
+		orphaned bool
+	}{
+		{name: "ttl expired", runID: "other", host: "other-host", pid: os.Getpid(), started: now.Add(-2 * time.Hour), ttl: time.Hour, orphaned: true},
+		{name: "ttl not expired on another host", runID: "other", host: "other-host", pid: deadPID(t), started: now, ttl: time.Hour, orphaned: false},
+		{name: "dead process on this host", runID: "other", host: "this-host", pid: deadPID(t), started: now, ttl: time.Hour, orphaned: true},
+		{name: "live process on this host", runID: "other", host: "this-host", pid: os.Getpid(), started: now, ttl: time.Hour, orphaned: false},
+		{name: "own run with expired ttl", runID: "current", host: "this-host", pid: deadPID(t), started: now.Add(-2 * time.Hour), ttl: time.Hour, orphaned: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stamped := &runMetadata{runID: tt.runID, host: tt.host, pid: tt.pid, startTime: tt.started, ttl: tt.ttl}
+			obj := &unstructured.Unstructured{}
+			stamped.stamp(obj, "scenario")
+
+			require.Equal(t, tt.orphaned, run.isOrphaned(obj, now))
+		})
+	}
+}
+
+func TestIsOrphanedWithoutRunAnnotations(t *testing.T) {
+	run := &runMetadata{runID: "current", host: "this-host", pid: 1, ttl: time.Hour}
+	obj := &unstructured.Unstructured{}
+	obj.SetLabels(map[string]string{LabelRunID: "other"})
+	obj.SetAnnotations(map[string]string{AnnotationHost: "this-host", AnnotationPID: "not-a-pid"})
+
+	require.False(t, run.isOrphaned(obj, time.Now()))
+}
+
+// deadPID returns the pid of a process that has exited
+func deadPID(t *testing.T) int {
+	cmd := exec.Command("true")
+	require.NoError(t, cmd.Run())
+	return cmd.Process.Pid
+}
@@ -0,0 +1,85 @@
+package chaosmesh
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnnotationExperimentName holds the name the experiment was defined with, before the naming policy applied
+const AnnotationExperimentName = "f1-chaos-mesh/experiment-name"
+
+// defaultNamespace is used when neither the plugin nor the kubeconfig context set a namespace
+const defaultNamespace = "default"
+
+// NamingPolicy decides the name experiments are created with in the cluster
+type NamingPolicy string
+
+const (
+	// NamingVerbatim creates experiments with the name they were defined with
+	NamingVerbatim NamingPolicy = ""
+	// NamingRunIDSuffix suffixes the names with the run ID, so that concurrent runs of a scenario do not collide
+	NamingRunIDSuffix NamingPolicy = "run-id-suffix"
+	// NamingGenerateName lets the API server generate unique names prefixed with the defined ones
+	NamingGenerateName NamingPolicy = "generate-name"
+)
+
+func (p NamingPolicy) validate() error {
+	switch p {
+	case NamingVerbatim, NamingRunIDSuffix, NamingGenerateName:
+		return nil
+	default:
+		return fmt.Errorf("unknown naming policy %q", p)
+	}
+}
+
+// nameExperiment returns a copy of the experiment with its namespace defaulted and the naming policy applied, the
+// name it was defined with is kept in an annotation. The final name is only known once a generated name is created.
+// The defined experiment is left untouched, so it can be created again by a later run.
+func (c *experimentsConfigurator) nameExperiment(defined client.Object) client.Object {
+	obj := defined.DeepCopyObject().(client.Object)
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(c.opts.namespace)
+	}
+
+	name := obj.GetName()
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationExperimentName] = name
+	obj.SetAnnotations(annotations)
+
+	switch c.opts.naming {
+	case NamingRunIDSuffix:
+		obj.SetName(fmt.Sprintf("%s-%s", name, c.run.runID))
+	case NamingGenerateName:
+		obj.SetGenerateName(name + "-")
+		obj.SetName("")
+	}
+	return obj
+}
+
+// definedName returns the name the experiment was defined with
+func definedName(obj client.Object) string {
+	if name, ok := obj.GetAnnotations()[AnnotationExperimentName]; ok {
+		return name
+	}
+	return obj.GetName()
+}
+
+// contextNamespace returns the namespace of the kubeconfig context the plugin uses
+func (o *options) contextNamespace() string {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if o.kubeconfig != "" {
+		rules.ExplicitPath = o.kubeconfig
+	}
+
+	namespace, _, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		rules, &clientcmd.ConfigOverrides{CurrentContext: o.context}).Namespace()
+	if err != nil || namespace == "" {
+		return defaultNamespace
+	}
+	return namespace
+}
@@ -0,0 +1,49 @@
+package chaosmesh
+
+import (
+	"testing"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNameExperiment(t *testing.T) {
+	tests := []struct {
+		policy       string
+		naming       NamingPolicy
+		name         string
+		generateName string
+	}{
+		{policy: "verbatim", naming: NamingVerbatim, name: "named"},
+		{policy: "run id suffix", naming: NamingRunIDSuffix, name: "named-run"},
+		{policy: "generate name", naming: NamingGenerateName, generateName: "named-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			c := newTestConfigurator(t, fake.NewClientBuilder().WithScheme(experimentsScheme).Build())
+			c.run = &runMetadata{runID: "run"}
+			c.opts.naming = tt.naming
+			c.opts.namespace = "chaos"
+
+			defined := workflowObject("named")
+			defined.Namespace = ""
+
+			// the same experiment is named once per run of the scenario
+			for i := 0; i < 2; i++ {
+				obj := c.nameExperiment(defined)
+
+				require.Equal(t, tt.name, obj.GetName())
+				require.Equal(t, tt.generateName, obj.GetGenerateName())
+				require.Equal(t, "chaos", obj.GetNamespace())
+				require.Equal(t, "named", definedName(obj))
+				require.IsType(t, &chaosmeshv1alpha1.Workflow{}, obj)
+			}
+
+			require.Equal(t, "named", defined.Name)
+			require.Empty(t, defined.Namespace)
+			require.Empty(t, defined.Annotations)
+		})
+	}
+}
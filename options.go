@@ -0,0 +1,219 @@
+package chaosmesh
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+// Option configures a ChaosPlugin
+type Option func(o *options)
+
+type options struct {
+	kubeconfig string
+	context    string
+	restConfig *rest.Config
+	kubeCli    client.Client
+	scheme     *runtime.Scheme
+
+	experimentDefaults experimentOptions
+	runTTL             time.Duration
+
+	interruptCleanupTimeout time.Duration
+
+	dryRun       DryRunMode
+	dryRunOutput io.Writer
+
+	concurrency      int
+	releaseAllAtOnce bool
+
+	// namespace is set on experiments defined without one, the kubeconfig context namespace by default
+	namespace string
+	naming    NamingPolicy
+
+	conflictPolicy ConflictPolicy
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		experimentDefaults: defaultExperimentOptions(),
+		runTTL:             defaultRunTTL,
+
+		interruptCleanupTimeout: defaultInterruptCleanupTimeout,
+
+		dryRun:       dryRunModeFromEnv(),
+		dryRunOutput: os.Stdout,
+
+		concurrency: 1,
+
+		conflictPolicy: ConflictFail,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithKubeconfig loads the cluster configuration from the given kubeconfig file
+func WithKubeconfig(path string) Option {
+	return func(o *options) {
+		o.kubeconfig = path
+	}
+}
+
+// WithContext selects the kubeconfig context used to reach the cluster
+func WithContext(context string) Option {
+	return func(o *options) {
+		o.context = context
+	}
+}
+
+// WithRESTConfig uses the given rest config instead of loading one from kubeconfig
+func WithRESTConfig(cfg *rest.Config) Option {
+	return func(o *options) {
+		o.restConfig = cfg
+	}
+}
+
+// WithClient uses a pre-built client, its scheme must know about the chaos mesh types.
+// Experiments are watched when it implements client.WithWatch and polled otherwise.
+func WithClient(cl client.Client) Option {
+	return func(o *options) {
+		o.kubeCli = cl
+	}
+}
+
+// WithScheme registers the chaos mesh types into the given scheme and uses it to build the client
+func WithScheme(scheme *runtime.Scheme) Option {
+	return func(o *options) {
+		o.scheme = scheme
+	}
+}
+
+// WithDefaultInjectionTimeout sets how long to wait for experiments to be injected, unless overridden per experiment
+func WithDefaultInjectionTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.experimentDefaults.injectionTimeout = timeout
+	}
+}
+
+// WithDefaultPollInterval sets how often experiments status is checked when they cannot be watched, unless overridden per experiment
+func WithDefaultPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.experimentDefaults.pollInterval = interval
+	}
+}
+
+// WithDefaultCleanupTimeout sets how long to wait for experiments to be cleaned up, unless overridden per experiment
+func WithDefaultCleanupTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.experimentDefaults.cleanupTimeout = timeout
+	}
+}
+
+// WithDefaultWaitForRecovery makes the cleanup of every experiment block until it is removed and its targets are recovered
+func WithDefaultWaitForRecovery(wait bool) Option {
+	return func(o *options) {
+		o.experimentDefaults.waitForRecovery = wait
+	}
+}
+
+// WithDefaultTemplate renders the files and yaml of every experiment with text/template, unless overridden per experiment
+func WithDefaultTemplate(render bool) Option {
+	return func(o *options) {
+		o.experimentDefaults.template = render
+	}
+}
+
+// WithRunTTL sets how long the experiments of this run are kept before GarbageCollect considers them orphaned
+func WithRunTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.runTTL = ttl
+	}
+}
+
+// WithInterruptCleanupTimeout bounds the time spent deleting live experiments when the process is interrupted,
+// the longest timeout of the plugins with live experiments applies
+func WithInterruptCleanupTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.interruptCleanupTimeout = timeout
+	}
+}
+
+// WithDryRun renders the experiments instead of creating them, the mode decides whether the scenario then runs without chaos or is aborted.
+// It takes precedence over the F1_CHAOS_MESH_DRY_RUN environment variable.
+func WithDryRun(mode DryRunMode) Option {
+	return func(o *options) {
+		o.dryRun = mode
+	}
+}
+
+// WithDryRunOutput sets where the manifests rendered by a dry run are written, stdout by default
+func WithDryRunOutput(w io.Writer) Option {
+	return func(o *options) {
+		o.dryRunOutput = w
+	}
+}
+
+// WithConcurrency sets how many experiments are created at the same time, one by default which keeps the order they were added in
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		if n < 1 {
+			n = 1
+		}
+		o.concurrency = n
+	}
+}
+
+// WithReleaseAllAtOnce creates the experiments paused and unpauses them together once all of them have selected their targets
+func WithReleaseAllAtOnce(release bool) Option {
+	return func(o *options) {
+		o.releaseAllAtOnce = release
+	}
+}
+
+// WithNamespace sets the namespace of the experiments defined without one, instead of the kubeconfig context namespace
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithNamingPolicy decides the names experiments are created with, the names they were defined with by default
+func WithNamingPolicy(policy NamingPolicy) Option {
+	return func(o *options) {
+		o.naming = policy
+	}
+}
+
+// WithConflictPolicy decides what happens when an experiment already exists in the cluster, the scenario fails by default
+func WithConflictPolicy(policy ConflictPolicy) Option {
+	return func(o *options) {
+		o.conflictPolicy = policy
+	}
+}
+
+func (o *options) validate() error {
+	return utilerrors.NewAggregate([]error{o.dryRun.validate(), o.naming.validate(), o.conflictPolicy.validate()})
+}
+
+func (o *options) getRESTConfig() (*rest.Config, error) {
+	if o.restConfig != nil {
+		return o.restConfig, nil
+	}
+
+	if o.kubeconfig == "" {
+		return config.GetConfigWithContext(o.context)
+	}
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: o.kubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: o.context}).ClientConfig()
+}
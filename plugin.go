@@ -1,36 +1,58 @@
 package chaosmesh
 
 import (
+	"errors"
+	"strings"
+
 	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/form3tech-oss/f1/pkg/f1/scenarios"
 	"github.com/form3tech-oss/f1/pkg/f1/testing"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
 type ChaosPlugin struct {
 	kubeCli client.Client
+	opts    *options
+	run     *runMetadata
 	initErr error
 }
 
-func NewChaosPlugin() *ChaosPlugin {
-	cp := &ChaosPlugin{}
+func NewChaosPlugin(opts ...Option) *ChaosPlugin {
+	o := newOptions(opts...)
+	if o.namespace == "" {
+		o.namespace = o.contextNamespace()
+	}
+	cp := &ChaosPlugin{
+		opts: o,
+		run:  newRunMetadata(o.runTTL),
+	}
+
+	if o.kubeCli != nil {
+		cp.kubeCli = o.kubeCli
+		return cp
+	}
 
-	cliConfig, err := config.GetConfig()
+	cliConfig, err := o.getRESTConfig()
 	if err != nil {
 		cp.initErr = err
 		return cp
 	}
 
-	scheme := runtime.NewScheme()
-	cl, err := client.New(cliConfig, client.Options{Scheme: scheme})
+	scheme := o.scheme
+	if scheme == nil {
+		scheme = runtime.NewScheme()
+	}
+
+	err = chaosmeshv1alpha1.AddToScheme(scheme)
 	if err != nil {
 		cp.initErr = err
 		return cp
 	}
 
-	err = chaosmeshv1alpha1.AddToScheme(scheme)
+	// the client watches experiments to find out when they are injected
+	cl, err := client.NewWithWatch(cliConfig, client.Options{Scheme: scheme})
 	if err != nil {
 		cp.initErr = err
 		return cp
@@ -41,6 +63,11 @@ func NewChaosPlugin() *ChaosPlugin {
 	return cp
 }
 
+// RunID returns the identifier labeling every experiment created by this plugin
+func (cp *ChaosPlugin) RunID() string {
+	return cp.run.runID
+}
+
 func (cp *ChaosPlugin) WithExperiments(cfn ChaosExperimentsConfigureFn) scenarios.ScenarioOption {
 	return func(s *scenarios.Scenario) {
 
@@ -50,28 +77,85 @@ func (cp *ChaosPlugin) WithExperiments(cfn ChaosExperimentsConfigureFn) scenario
 
 func (cp *ChaosPlugin) wrapScenarioWithExperiments(s testing.ScenarioFn, cfn ChaosExperimentsConfigureFn) testing.ScenarioFn {
 	return func(t *testing.T) testing.RunFn {
-		if cp.initErr != nil {
+		err := cp.opts.validate()
+		if err != nil {
+			t.Fatalf("Invalid chaos plugin configuration: %s", err)
+		}
+
+		// a dry run without a cluster renders the experiments offline
+		if cp.initErr != nil && cp.opts.dryRun == DryRunDisabled {
 			t.Fatalf("Could not initialize chaos plugin correctly: %s", cp.initErr)
 		}
 
-		experimentsBuilder := newChaosExperimentsBuilder()
+		experimentsBuilder := newChaosExperimentsBuilder(cp.opts.experimentDefaults)
+		experimentsBuilder.templateData.Scenario = t.Scenario
+		experimentsBuilder.templateData.RunID = cp.run.runID
 		cfn(experimentsBuilder)
-		experiments := experimentsBuilder.build()
+		experiments, err := experimentsBuilder.build()
+		if err != nil {
+			t.Fatalf("Invalid chaos experiments:%s", formatErrors(err))
+		}
+
+		ec := newExperimentsConfigurator(t, cp.kubeCli, experiments, cp.run, cp.opts)
+
+		if cp.opts.dryRun != DryRunDisabled {
+			err = ec.DryRunExperiments()
+			if err != nil {
+				t.Fatalf("Dry run of chaos experiments failed: %s", err)
+			}
+			if cp.opts.dryRun == DryRunAbort {
+				t.Fatalf("Dry run of chaos experiments finished, aborting the scenario")
+			}
+			t.Logger.Warn("Dry run of chaos experiments finished, running the scenario without chaos")
+			return s(t)
+		}
 
-		ec := newExperimentsConfigurator(t, cp.kubeCli, experiments)
+		ctrl := &ExperimentsController{c: ec}
 
 		t.Cleanup(func() {
+			unregisterController(t.Scenario, ctrl)
+			ec.StopTimeline()
 			err := ec.CleanupExperiments()
 			t.Require.NoError(err)
 		})
 
-		// Configure Experiments
-		err := ec.ConfigureExperiments()
+		// Configure Experiments, on failure the experiments already created are rolled back
+		err = ec.ConfigureExperiments()
 		if err != nil {
-			ec.CleanupExperiments()
-			t.FailNow()
+			t.Fatalf("Could not configure chaos experiments: %s", err)
+		}
+
+		registerController(t.Scenario, ctrl)
+		for _, hook := range experiments.controllerHooks {
+			hook(ctrl)
+		}
+
+		runFn := s(t)
+
+		// the load test starts once the scenario is set up, delayed experiments are timed from here
+		ec.StartTimeline()
+
+		return func(t *testing.T) {
+			err := ec.RunError()
+			if err != nil {
+				t.Fatalf("Chaos experiments failed during the run: %s", err)
+			}
+			runFn(t)
 		}
+	}
+}
+
+// formatErrors lists every error of an aggregate on its own line
+func formatErrors(err error) string {
+	var agg utilerrors.Aggregate
+	if !errors.As(err, &agg) {
+		return " " + err.Error()
+	}
 
-		return s(t)
+	var sb strings.Builder
+	for _, e := range agg.Errors() {
+		sb.WriteString("\n - ")
+		sb.WriteString(e.Error())
 	}
+	return sb.String()
 }
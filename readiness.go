@@ -0,0 +1,233 @@
+package chaosmesh
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readinessCheck reports whether the live experiment reached the state the scenario waits for,
+// along with a description of its current state for the logs
+type readinessCheck func(live *unstructured.Unstructured) (ready bool, state string, err error)
+
+// waitForExperimentsToBeReady waits for every created experiment to be injected
+func (c *experimentsConfigurator) waitForExperimentsToBeReady(created []*createdExperiment) error {
+	return c.waitForExperiments(created, "ready", c.readinessCheckFor)
+}
+
+// waitForExperiments waits for every experiment concurrently, it returns once all of them
+// pass the check of their kind or as soon as their timeouts expire
+func (c *experimentsConfigurator) waitForExperiments(created []*createdExperiment, state string, checkFor func(exp *createdExperiment) readinessCheck) error {
+	errs := make([]error, len(created))
+	var wg sync.WaitGroup
+	for i, exp := range created {
+		wg.Add(1)
+		go func(i int, exp *createdExperiment) {
+			defer wg.Done()
+			err := c.waitForExperiment(exp.gvk, exp.obj, exp.opts, state, checkFor(exp))
+			if err != nil {
+				expFriendlyName := generateExperimentFriendlyName(exp.gvk.Kind, exp.obj.GetNamespace(), exp.obj.GetName())
+				errs[i] = errors.Wrapf(err, "chaos experiment %s failed", expFriendlyName)
+			}
+		}(i, exp)
+	}
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *experimentsConfigurator) waitForExperiment(gvk schema.GroupVersionKind, obj client.Object, opts *experimentOptions, state string, check readinessCheck) error {
+	expFriendlyName := generateExperimentFriendlyName(gvk.Kind, obj.GetNamespace(), obj.GetName())
+	c.t.Logger.Infof("Waiting for chaos experiment %s to be %s (injection timeout: %s)", expFriendlyName, state, opts.injectionTimeout)
+
+	// workflow nodes are separate objects, changes to them are not seen by watching the workflow
+	watchable := len(opts.readyWorkflowNodes) == 0
+
+	err := c.waitUntil(gvk, obj, opts.injectionTimeout, opts.pollInterval, check, watchable)
+	if err != nil {
+		c.t.Logger.Errorf("Chaos experiment %s was not %s, err: %s", expFriendlyName, state, err)
+		return err
+	}
+
+	c.t.Logger.Infof("Chaos experiment %s is %s", expFriendlyName, state)
+	return nil
+}
+
+// waitUntil evaluates the check on every change of the live experiment, watching it when the client supports watches.
+// Without watches, or when a watch cannot be established, the experiment is fetched again every poll interval.
+func (c *experimentsConfigurator) waitUntil(gvk schema.GroupVersionKind, obj client.Object, timeout time.Duration, pollInterval time.Duration, check readinessCheck, watchable bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	expFriendlyName := generateExperimentFriendlyName(gvk.Kind, obj.GetNamespace(), obj.GetName())
+
+	// only changes of state are logged, a watch may deliver many updates in the same state
+	lastState := notCreated
+	observe := func(live *unstructured.Unstructured) (bool, error) {
+		ready, state, err := check(live)
+		if err == nil && !ready && state != lastState {
+			c.t.Logger.Infof("Chaos experiment %s is %s", expFriendlyName, state)
+		}
+		lastState = state
+		return ready, err
+	}
+
+	watchCli, canWatch := c.kubeCli.(client.WithWatch)
+	canWatch = canWatch && watchable
+	for {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(gvk)
+		err := c.kubeCli.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, live)
+		if err == nil {
+			ready, err := observe(live)
+			if err != nil || ready {
+				return err
+			}
+		} else if ctx.Err() == nil {
+			c.t.Logger.Infof("Could not get chaos experiment %s, err: %s", expFriendlyName, err)
+		}
+
+		watched := false
+		if canWatch && err == nil {
+			w, err := watchExperiment(ctx, watchCli, gvk, live)
+			if err != nil {
+				// without the watch verb for instance, the experiment is polled from now on
+				c.t.Logger.Infof("Could not watch chaos experiment %s, polling it instead, err: %s", expFriendlyName, err)
+				canWatch = false
+			} else {
+				watched = true
+				ready, err := watchUntil(ctx, w, observe)
+				if err != nil || ready {
+					return err
+				}
+			}
+		}
+		if !watched {
+			select {
+			case <-ctx.Done():
+			case <-time.After(pollInterval):
+			}
+		}
+
+		if ctx.Err() != nil {
+			return errors.Errorf("timed out after %s, %s", timeout, lastState)
+		}
+	}
+}
+
+// watchExperiment watches the experiment from the resource version of the given live object
+func watchExperiment(ctx context.Context, watchCli client.WithWatch, gvk schema.GroupVersionKind, live *unstructured.Unstructured) (watch.Interface, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	return watchCli.Watch(ctx, list,
+		&client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: live.GetResourceVersion()}},
+		client.InNamespace(live.GetNamespace()),
+		client.MatchingFields{"metadata.name": live.GetName()})
+}
+
+// watchUntil evaluates the check on every change delivered by the watch until it passes.
+// It returns false without an error when the watch ends early, so the caller can resync and watch again.
+func watchUntil(ctx context.Context, w watch.Interface, check func(live *unstructured.Unstructured) (bool, error)) (bool, error) {
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			if ev.Type != watch.Added && ev.Type != watch.Modified {
+				continue
+			}
+			u, err := toUnstructured(ev.Object)
+			if err != nil {
+				continue
+			}
+			ready, err := check(u)
+			if err != nil || ready {
+				return ready, err
+			}
+		}
+	}
+}
+
+// toUnstructured converts the objects of a watch, which are typed when the client knows their kind
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// readinessCheckFor returns the check deciding when an experiment is ready,
+// made of its readiness options when it has any and otherwise of the injection of its kind
+func (c *experimentsConfigurator) readinessCheckFor(exp *createdExperiment) readinessCheck {
+	if !exp.opts.hasReadinessOverrides() {
+		return injectedCheckFor(exp.gvk)
+	}
+
+	var checks []readinessCheck
+	if len(exp.opts.readyConditions) > 0 {
+		checks = append(checks, chaosConditionsTrue(exp.opts.readyConditions))
+	}
+	if len(exp.opts.readyWorkflowConditions) > 0 {
+		checks = append(checks, workflowConditionsTrue(exp.opts.readyWorkflowConditions))
+	}
+	if len(exp.opts.readyWorkflowNodes) > 0 {
+		checks = append(checks, c.workflowNodesStarted(exp.opts.readyWorkflowNodes))
+	}
+	if exp.opts.readyWhen != nil {
+		checks = append(checks, predicateHolds(exp.opts.readyWhen))
+	}
+	return allOf(checks)
+}
+
+// workflowNodesStarted waits until the workflow has spawned a node for each of the given templates
+func (c *experimentsConfigurator) workflowNodesStarted(templates []string) readinessCheck {
+	return func(live *unstructured.Unstructured) (bool, string, error) {
+		var nodes chaosmeshv1alpha1.WorkflowNodeList
+		err := c.kubeCli.List(
+			context.Background(),
+			&nodes,
+			client.InNamespace(live.GetNamespace()),
+			client.MatchingLabels{chaosmeshv1alpha1.LabelWorkflow: live.GetName()})
+		if err != nil {
+			return false, fmt.Sprintf("unable to list workflow nodes, %s", err), nil
+		}
+
+		started := map[string]bool{}
+		for _, node := range nodes.Items {
+			started[node.Spec.TemplateName] = true
+		}
+
+		var pending []string
+		for _, template := range templates {
+			if !started[template] {
+				pending = append(pending, template)
+			}
+		}
+		if len(pending) > 0 {
+			return false, fmt.Sprintf("waiting for the nodes of templates %v to start", pending), nil
+		}
+		return true, fmt.Sprintf("nodes of templates %v started", templates), nil
+	}
+}
@@ -0,0 +1,128 @@
+package chaosmesh
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	f1testing "github.com/form3tech-oss/f1/pkg/f1/testing"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReadinessIsDrivenByTheWatch(t *testing.T) {
+	obj := networkChaosObject("watched")
+	kubeCli := &watchNotifyingClient{
+		WithWatch: fake.NewClientBuilder().WithScheme(experimentsScheme).WithObjects(obj).Build(),
+		watching:  make(chan struct{}),
+	}
+	c := newTestConfigurator(t, kubeCli)
+
+	// the experiment would only be seen injected by polling after an hour
+	errs := make(chan error)
+	go func() {
+		errs <- c.waitUntil(obj.GroupVersionKind(), obj, 5*time.Second, time.Hour, chaosInjected, true)
+	}()
+
+	<-kubeCli.watching
+	injectExperiment(t, kubeCli, obj)
+
+	require.NoError(t, <-errs)
+}
+
+func TestReadinessIsPolledWithoutWatches(t *testing.T) {
+	obj := networkChaosObject("polled")
+	kubeCli := &plainClient{Client: fake.NewClientBuilder().WithScheme(experimentsScheme).WithObjects(obj).Build()}
+	c := newTestConfigurator(t, kubeCli)
+
+	errs := make(chan error)
+	go func() {
+		errs <- c.waitUntil(obj.GroupVersionKind(), obj, 5*time.Second, 50*time.Millisecond, chaosInjected, true)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	injectExperiment(t, kubeCli, obj)
+
+	require.NoError(t, <-errs)
+}
+
+func TestReadinessIsPolledWhenTheWatchFails(t *testing.T) {
+	obj := networkChaosObject("forbidden-watch")
+	kubeCli := &failingWatchClient{WithWatch: fake.NewClientBuilder().WithScheme(experimentsScheme).WithObjects(obj).Build()}
+	c := newTestConfigurator(t, kubeCli)
+
+	errs := make(chan error)
+	go func() {
+		errs <- c.waitUntil(obj.GroupVersionKind(), obj, 5*time.Second, 50*time.Millisecond, chaosInjected, true)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	injectExperiment(t, kubeCli, obj)
+
+	require.NoError(t, <-errs)
+	require.Equal(t, int32(1), atomic.LoadInt32(&kubeCli.watches), "the watch was retried")
+}
+
+func TestReadinessTimesOutWithTheLastState(t *testing.T) {
+	obj := networkChaosObject("never-injected")
+	kubeCli := &plainClient{Client: fake.NewClientBuilder().WithScheme(experimentsScheme).WithObjects(obj).Build()}
+	c := newTestConfigurator(t, kubeCli)
+
+	err := c.waitUntil(obj.GroupVersionKind(), obj, 200*time.Millisecond, 50*time.Millisecond, chaosInjected, true)
+
+	require.EqualError(t, err, "timed out after 200ms, "+notYetReconciled)
+}
+
+func newTestConfigurator(t *testing.T, kubeCli client.Client) *experimentsConfigurator {
+	ft, teardown := f1testing.NewT("1", t.Name())
+	t.Cleanup(teardown)
+	return &experimentsConfigurator{kubeCli: kubeCli, t: ft, opts: newOptions()}
+}
+
+// injectExperiment writes the status chaos mesh writes once the experiment is injected
+func injectExperiment(t *testing.T, kubeCli client.Client, obj *unstructured.Unstructured) {
+	live := obj.DeepCopy()
+	require.NoError(t, kubeCli.Get(context.Background(), client.ObjectKeyFromObject(obj), live))
+	require.NoError(t, unstructured.SetNestedSlice(live.Object, []interface{}{
+		map[string]interface{}{"type": string(chaosmeshv1alpha1.ConditionAllInjected), "status": "True"},
+	}, "status", "conditions"))
+	require.NoError(t, kubeCli.Update(context.Background(), live))
+}
+
+// plainClient hides the watch support of the client it wraps
+type plainClient struct {
+	client.Client
+}
+
+// failingWatchClient cannot watch, as without the watch verb
+type failingWatchClient struct {
+	client.WithWatch
+	watches int32
+}
+
+func (c *failingWatchClient) Watch(context.Context, client.ObjectList, ...client.ListOption) (watch.Interface, error) {
+	atomic.AddInt32(&c.watches, 1)
+	return nil, errors.New("watch is forbidden")
+}
+
+// watchNotifyingClient closes watching once the first watch is established
+type watchNotifyingClient struct {
+	client.WithWatch
+	watching chan struct{}
+}
+
+func (c *watchNotifyingClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	w, err := c.WithWatch.Watch(ctx, list, opts...)
+	select {
+	case <-c.watching:
+	default:
+		close(c.watching)
+	}
+	return w, err
+}
@@ -0,0 +1,140 @@
+package chaosmesh
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// configureExperimentsAllAtOnce creates the experiments paused and holds them until every one of them has selected
+// its targets, then releases them together so that all faults become active within a small time window.
+// Workflows and pod chaos cannot be paused, they are created when the other experiments are released.
+func (c *experimentsConfigurator) configureExperimentsAllAtOnce(planned []*plannedExperiment) error {
+	var held, unpausable []*plannedExperiment
+	for _, p := range planned {
+		if p.gvk.Kind == chaosmeshv1alpha1.KindWorkflow || isPodChaos(p.gvk) {
+			unpausable = append(unpausable, p)
+			continue
+		}
+		// the defined experiment is left untouched, a later run may create it unpaused
+		paused := p.obj.DeepCopyObject().(client.Object)
+		setPauseAnnotation(paused, true)
+		held = append(held, &plannedExperiment{gvk: p.gvk, obj: paused, opts: p.opts})
+	}
+
+	err := c.createExperiments(held)
+	if err != nil {
+		return c.rollback(err)
+	}
+
+	err = c.waitForExperiments(c.createdExperiments(), "held", heldCheckFor)
+	if err != nil {
+		return c.rollback(err)
+	}
+
+	c.t.Logger.Infof("Releasing %d chaos experiments at once", len(held))
+
+	err = c.releaseExperiments(c.createdExperiments())
+	if err != nil {
+		return c.rollback(err)
+	}
+
+	err = c.createExperiments(unpausable)
+	if err != nil {
+		return c.rollback(err)
+	}
+
+	err = c.waitForExperimentsToBeReady(c.createdExperiments())
+	if err != nil {
+		return c.rollback(err)
+	}
+
+	c.t.Logger.Info("Chaos experiments are ready")
+	return nil
+}
+
+// releaseExperiments unpauses every experiment concurrently
+func (c *experimentsConfigurator) releaseExperiments(created []*createdExperiment) error {
+	errs := make([]error, len(created))
+	var wg sync.WaitGroup
+	for i, exp := range created {
+		wg.Add(1)
+		go func(i int, exp *createdExperiment) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), exp.opts.injectionTimeout)
+			defer cancel()
+
+			err := c.patchPaused(ctx, exp.obj, false)
+			if err != nil {
+				expFriendlyName := generateExperimentFriendlyName(exp.gvk.Kind, exp.obj.GetNamespace(), exp.obj.GetName())
+				errs[i] = errors.Wrapf(err, "could not release chaos experiment %s", expFriendlyName)
+			}
+		}(i, exp)
+	}
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// patchPaused sets or removes the chaos mesh pause annotation of a live experiment. The tracked object is shared
+// with the cleanup and the interrupt registry, a copy is patched and the patch does not depend on its annotations.
+func (c *experimentsConfigurator) patchPaused(ctx context.Context, obj client.Object, paused bool) error {
+	// a null annotation removes it
+	var value interface{}
+	if paused {
+		value = "true"
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{chaosmeshv1alpha1.PauseAnnotationKey: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return c.kubeCli.Patch(ctx, obj.DeepCopyObject().(client.Object), client.RawPatch(types.MergePatchType, patch))
+}
+
+func setPauseAnnotation(obj client.Object, paused bool) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	if paused {
+		annotations[chaosmeshv1alpha1.PauseAnnotationKey] = "true"
+	} else {
+		delete(annotations, chaosmeshv1alpha1.PauseAnnotationKey)
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// heldCheckFor returns the check deciding when a paused experiment is ready to be released
+func heldCheckFor(exp *createdExperiment) readinessCheck {
+	switch exp.gvk.Kind {
+	case chaosmeshv1alpha1.KindSchedule:
+		// a paused schedule spawns nothing, there is nothing to wait for
+		return func(*unstructured.Unstructured) (bool, string, error) { return true, "paused", nil }
+	default:
+		return chaosHeld
+	}
+}
+
+// chaosHeld considers a paused chaos ready once it has selected its targets
+func chaosHeld(live *unstructured.Unstructured) (bool, string, error) {
+	status, reconciled := chaosStatusOf(live)
+	if !reconciled {
+		return false, notYetReconciled, nil
+	}
+
+	ready := experimentHasCondition(status, chaosmeshv1alpha1.ConditionPaused) &&
+		experimentHasCondition(status, chaosmeshv1alpha1.ConditionSelected)
+	return ready, describeChaosConditions(status), nil
+}
@@ -0,0 +1,229 @@
+package chaosmesh
+
+import (
+	"fmt"
+	"strings"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// notCreated is the state of an experiment before it is first seen in the cluster
+	notCreated = "not yet created"
+	// notYetReconciled is the state of an experiment whose controller has not written its status yet
+	notYetReconciled = "not yet reconciled"
+)
+
+// injectedCheckFor returns the check deciding when an experiment of the given kind is injected
+func injectedCheckFor(gvk schema.GroupVersionKind) readinessCheck {
+	switch gvk.Kind {
+	case chaosmeshv1alpha1.KindWorkflow:
+		return workflowScheduled
+	case chaosmeshv1alpha1.KindSchedule:
+		return scheduleSpawned
+	case "PodHttpChaos", "PodIOChaos", "PodNetworkChaos":
+		return podChaosApplied
+	default:
+		return chaosInjected
+	}
+}
+
+// isPodChaos reports whether the kind is one of the pod chaos kinds, which have no conditions and cannot be paused
+func isPodChaos(gvk schema.GroupVersionKind) bool {
+	switch gvk.Kind {
+	case "PodHttpChaos", "PodIOChaos", "PodNetworkChaos":
+		return true
+	default:
+		return false
+	}
+}
+
+// chaosInjected waits for the AllInjected condition of the chaos status
+func chaosInjected(live *unstructured.Unstructured) (bool, string, error) {
+	status, reconciled := chaosStatusOf(live)
+	if !reconciled {
+		return false, notYetReconciled, nil
+	}
+
+	return experimentHasCondition(status, chaosmeshv1alpha1.ConditionAllInjected), describeChaosConditions(status), nil
+}
+
+// workflowScheduled waits for the Scheduled condition of the workflow
+func workflowScheduled(live *unstructured.Unstructured) (bool, string, error) {
+	wf, reconciled := workflowOf(live)
+	if !reconciled {
+		return false, notYetReconciled, nil
+	}
+
+	return workflowHasCondition(wf.Status, chaosmeshv1alpha1.WorkflowConditionScheduled), describeWorkflowConditions(wf.Status), nil
+}
+
+// scheduleSpawned considers a schedule accepted once it has spawned its first child, it has no conditions
+func scheduleSpawned(live *unstructured.Unstructured) (bool, string, error) {
+	var sc chaosmeshv1alpha1.Schedule
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(live.Object, &sc)
+	if err != nil {
+		return false, fmt.Sprintf("unreadable, %s", err), nil
+	}
+
+	if len(sc.Status.Active) == 0 {
+		return false, "waiting for the first scheduled experiment", nil
+	}
+	return true, fmt.Sprintf("%d active experiments", len(sc.Status.Active)), nil
+}
+
+// podChaosApplied waits for the chaos daemon to apply the current generation of the
+// PodHttpChaos, PodIOChaos and PodNetworkChaos kinds, which have no conditions
+func podChaosApplied(live *unstructured.Unstructured) (bool, string, error) {
+	observedGeneration, found, err := unstructured.NestedInt64(live.Object, "status", "observedGeneration")
+	if err != nil || !found || observedGeneration == 0 {
+		return false, notYetReconciled, nil
+	}
+
+	failedMessage, _, _ := unstructured.NestedString(live.Object, "status", "failedMessage")
+	if failedMessage != "" {
+		return false, fmt.Sprintf("failing, %s", failedMessage), nil
+	}
+
+	if observedGeneration < live.GetGeneration() {
+		return false, fmt.Sprintf("applying generation %d, observed %d", live.GetGeneration(), observedGeneration), nil
+	}
+	return true, "applied", nil
+}
+
+// chaosConditionsTrue waits for all the given conditions of the chaos status
+func chaosConditionsTrue(conditions []chaosmeshv1alpha1.ChaosConditionType) readinessCheck {
+	return func(live *unstructured.Unstructured) (bool, string, error) {
+		status, reconciled := chaosStatusOf(live)
+		if !reconciled {
+			return false, notYetReconciled, nil
+		}
+
+		for _, condition := range conditions {
+			if !experimentHasCondition(status, condition) {
+				return false, describeChaosConditions(status), nil
+			}
+		}
+		return true, describeChaosConditions(status), nil
+	}
+}
+
+// workflowConditionsTrue waits for all the given conditions of the workflow status
+func workflowConditionsTrue(conditions []chaosmeshv1alpha1.WorkflowConditionType) readinessCheck {
+	return func(live *unstructured.Unstructured) (bool, string, error) {
+		wf, reconciled := workflowOf(live)
+		if !reconciled {
+			return false, notYetReconciled, nil
+		}
+
+		for _, condition := range conditions {
+			if !workflowHasCondition(wf.Status, condition) {
+				return false, describeWorkflowConditions(wf.Status), nil
+			}
+		}
+		return true, describeWorkflowConditions(wf.Status), nil
+	}
+}
+
+// chaosPaused waits for the Paused condition to reach the given value
+func chaosPaused(paused bool) readinessCheck {
+	return func(live *unstructured.Unstructured) (bool, string, error) {
+		status, reconciled := chaosStatusOf(live)
+		if !reconciled {
+			return false, notYetReconciled, nil
+		}
+
+		return experimentHasCondition(status, chaosmeshv1alpha1.ConditionPaused) == paused, describeChaosConditions(status), nil
+	}
+}
+
+// predicateHolds adapts a user supplied predicate
+func predicateHolds(predicate ReadyPredicate) readinessCheck {
+	return func(live *unstructured.Unstructured) (bool, string, error) {
+		ready, err := predicate(live)
+		return ready, "waiting for the ready predicate", err
+	}
+}
+
+// allOf is ready once every check is, its state is the one of the first check that is not ready
+func allOf(checks []readinessCheck) readinessCheck {
+	return func(live *unstructured.Unstructured) (bool, string, error) {
+		state := ""
+		for _, check := range checks {
+			ready, checkState, err := check(live)
+			if err != nil || !ready {
+				return false, checkState, err
+			}
+			state = checkState
+		}
+		return true, state, nil
+	}
+}
+
+// chaosStatusOf decodes the chaos status of a live experiment,
+// it is not reconciled until the controller has written its conditions
+func chaosStatusOf(live *unstructured.Unstructured) (chaosmeshv1alpha1.ChaosStatus, bool) {
+	var status chaosmeshv1alpha1.ChaosStatus
+	unstructuredStatus, found, err := unstructured.NestedMap(live.Object, "status")
+	if err != nil || !found {
+		return status, false
+	}
+
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredStatus, &status)
+	if err != nil {
+		return status, false
+	}
+
+	return status, len(status.Conditions) > 0
+}
+
+// workflowOf decodes a live workflow, it is not reconciled until the controller has written its conditions
+func workflowOf(live *unstructured.Unstructured) (*chaosmeshv1alpha1.Workflow, bool) {
+	var wf chaosmeshv1alpha1.Workflow
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(live.Object, &wf)
+	if err != nil {
+		return nil, false
+	}
+
+	return &wf, len(wf.Status.Conditions) > 0
+}
+
+func experimentHasCondition(status chaosmeshv1alpha1.ChaosStatus, condition chaosmeshv1alpha1.ChaosConditionType) bool {
+	for _, sc := range status.Conditions {
+		if sc.Type == condition && sc.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func workflowHasCondition(status chaosmeshv1alpha1.WorkflowStatus, condition chaosmeshv1alpha1.WorkflowConditionType) bool {
+	for _, sc := range status.Conditions {
+		if sc.Type == condition && sc.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// describeChaosConditions lists the conditions of a chaos, e.g. "Selected=True, AllInjected=False"
+func describeChaosConditions(status chaosmeshv1alpha1.ChaosStatus) string {
+	conditions := make([]string, 0, len(status.Conditions))
+	for _, sc := range status.Conditions {
+		conditions = append(conditions, fmt.Sprintf("%s=%s", sc.Type, sc.Status))
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// describeWorkflowConditions lists the conditions of a workflow, e.g. "Scheduled=True, Accomplished=False"
+func describeWorkflowConditions(status chaosmeshv1alpha1.WorkflowStatus) string {
+	conditions := make([]string, 0, len(status.Conditions))
+	for _, sc := range status.Conditions {
+		conditions = append(conditions, fmt.Sprintf("%s=%s", sc.Type, sc.Status))
+	}
+	return strings.Join(conditions, ", ")
+}
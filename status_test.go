@@ -0,0 +1,160 @@
+package chaosmesh
+
+import (
+	"testing"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestChaosStatusOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     interface{}
+		reconciled bool
+		state      string
+	}{
+		{name: "missing status", reconciled: false},
+		{name: "status without conditions", status: map[string]interface{}{
+			"experiment": map[string]interface{}{"desiredPhase": "Run"},
+		}, reconciled: false},
+		{name: "status with conditions", status: map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Selected", "status": "True"},
+				map[string]interface{}{"type": "AllInjected", "status": "False"},
+			},
+		}, reconciled: true, state: "Selected=True, AllInjected=False"},
+		{name: "status of an unexpected type", status: "broken", reconciled: false},
+		{name: "conditions of an unexpected type", status: map[string]interface{}{
+			"conditions": "broken",
+		}, reconciled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tt.status != nil {
+				live.Object["status"] = tt.status
+			}
+
+			status, reconciled := chaosStatusOf(live)
+
+			require.Equal(t, tt.reconciled, reconciled)
+			require.Equal(t, tt.state, describeChaosConditions(status))
+		})
+	}
+}
+
+func TestWorkflowOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		object     map[string]interface{}
+		decoded    bool
+		reconciled bool
+		state      string
+	}{
+		{name: "missing status", object: map[string]interface{}{
+			"spec": map[string]interface{}{"entry": "entry"},
+		}, decoded: true, reconciled: false},
+		{name: "status without conditions", object: map[string]interface{}{
+			"status": map[string]interface{}{"entryNode": "entry-node"},
+		}, decoded: true, reconciled: false},
+		{name: "status with conditions", object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Scheduled", "status": "True"},
+					map[string]interface{}{"type": "Accomplished", "status": "False"},
+				},
+			},
+		}, decoded: true, reconciled: true, state: "Scheduled=True, Accomplished=False"},
+		{name: "status of an unexpected type", object: map[string]interface{}{
+			"status": "broken",
+		}, decoded: false, reconciled: false},
+		{name: "spec of an unexpected type", object: map[string]interface{}{
+			"spec": []interface{}{"broken"},
+		}, decoded: false, reconciled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, reconciled := workflowOf(&unstructured.Unstructured{Object: tt.object})
+
+			require.Equal(t, tt.reconciled, reconciled)
+			if !tt.decoded {
+				require.Nil(t, wf)
+				return
+			}
+			require.NotNil(t, wf)
+			require.Equal(t, tt.state, describeWorkflowConditions(wf.Status))
+		})
+	}
+}
+
+func TestPodChaosApplied(t *testing.T) {
+	tests := []struct {
+		name       string
+		generation int64
+		status     interface{}
+		applied    bool
+		state      string
+	}{
+		{name: "missing status", generation: 1, applied: false, state: notYetReconciled},
+		{name: "status without an observed generation", generation: 1, status: map[string]interface{}{
+			"failedMessage": "",
+		}, applied: false, state: notYetReconciled},
+		{name: "observed generation of an unexpected type", generation: 1, status: map[string]interface{}{
+			"observedGeneration": "1",
+		}, applied: false, state: notYetReconciled},
+		{name: "status of an unexpected type", generation: 1, status: "broken", applied: false, state: notYetReconciled},
+		{name: "failing", generation: 1, status: map[string]interface{}{
+			"observedGeneration": int64(1),
+			"failedMessage":      "no such container",
+		}, applied: false, state: "failing, no such container"},
+		{name: "older generation observed", generation: 2, status: map[string]interface{}{
+			"observedGeneration": int64(1),
+		}, applied: false, state: "applying generation 2, observed 1"},
+		{name: "current generation observed", generation: 2, status: map[string]interface{}{
+			"observedGeneration": int64(2),
+		}, applied: true, state: "applied"},
+		{name: "failed message of an unexpected type", generation: 1, status: map[string]interface{}{
+			"observedGeneration": int64(1),
+			"failedMessage":      int64(1),
+		}, applied: true, state: "applied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			live.SetGeneration(tt.generation)
+			if tt.status != nil {
+				live.Object["status"] = tt.status
+			}
+
+			applied, state, err := podChaosApplied(live)
+
+			require.NoError(t, err)
+			require.Equal(t, tt.applied, applied)
+			require.Equal(t, tt.state, state)
+		})
+	}
+}
+
+func TestChaosInjected(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": string(chaosmeshv1alpha1.ConditionAllInjected), "status": "True"},
+			},
+		},
+	}}
+	injected, state, err := chaosInjected(live)
+	require.NoError(t, err)
+	require.True(t, injected)
+	require.Equal(t, "AllInjected=True", state)
+
+	injected, state, err = chaosInjected(&unstructured.Unstructured{Object: map[string]interface{}{}})
+	require.NoError(t, err)
+	require.False(t, injected)
+	require.Equal(t, notYetReconciled, state)
+}
@@ -0,0 +1,307 @@
+package chaosmesh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// TemplateData is available to experiments rendered as templates
+type TemplateData struct {
+	// Scenario is the name of the f1 scenario
+	Scenario string
+	// RunID identifies the experiments of this run, see ChaosPlugin.RunID
+	RunID string
+	// Rate and MaxDuration are read from the f1 run command line, with the defaults of f1 when they are not given.
+	// The rate is only known for the constant trigger and neither is for the file trigger, they are empty then
+	// and manifests using them fail to render.
+	Rate        string
+	MaxDuration string
+	// Values are the values passed to the builder with WithTemplateValues
+	Values map[string]interface{}
+}
+
+// newTemplateData gathers the variables of the scenario being set up
+func newTemplateData(scenario, runID string, args []string) TemplateData {
+	rate, maxDuration := runFlags(args)
+	return TemplateData{
+		Scenario:    scenario,
+		RunID:       runID,
+		Rate:        rate,
+		MaxDuration: maxDuration,
+		Values:      map[string]interface{}{},
+	}
+}
+
+// runFlags parses the rate and the max duration of the f1 run command line the way f1 defines them, f1 does not
+// expose its run options to scenarios. The file trigger reads both from its file and only the constant one has a rate.
+func runFlags(args []string) (rate string, maxDuration string) {
+	trigger := ""
+	for i, arg := range args {
+		if arg == "run" && i+1 < len(args) {
+			trigger = args[i+1]
+			break
+		}
+	}
+	if trigger == "" || trigger == "file" {
+		return "", ""
+	}
+
+	flags := pflag.NewFlagSet(trigger, pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.String("cpuprofile", "", "")
+	flags.String("memprofile", "", "")
+	flags.BoolP("verbose", "v", false, "")
+	flags.Bool("verbose-fail", false, "")
+	flags.Bool("ignore-dropped", false, "")
+	flags.IntP("concurrency", "c", 100, "")
+	flags.Int32P("max-iterations", "i", 0, "")
+	duration := flags.DurationP("max-duration", "d", time.Second, "")
+	if trigger == "constant" {
+		flags.StringP("rate", "r", "1/s", "")
+		flags.Float64P("jitter", "j", 0, "")
+		flags.String("distribution", "regular", "")
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return "", ""
+	}
+	if trigger == "constant" {
+		rate, _ = flags.GetString("rate")
+	}
+	return rate, duration.String()
+}
+
+// missingAsEmptyFunc is the helper appended to the printed actions of the templates
+const missingAsEmptyFunc = "_missingAsEmpty"
+
+// renderTemplate executes the manifest as a template. Missing values are nil so that they can be defaulted,
+// and render empty when printed. Manifests using a field of the command line that cannot be resolved fail.
+func renderTemplate(name string, manifest []byte, data TemplateData) ([]byte, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=zero").
+		Funcs(templateFuncs).
+		Funcs(template.FuncMap{missingAsEmptyFunc: missingAsEmpty}).
+		Parse(string(manifest))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing template %s", name)
+	}
+
+	used := map[string]bool{}
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		printMissingAsEmpty(t.Tree, t.Tree.Root)
+		// the dot of the other defined templates is the one they are called with
+		usedFields(t.Tree.Root, t.Name() == name, used)
+	}
+	for field, value := range map[string]string{"Rate": data.Rate, "MaxDuration": data.MaxDuration} {
+		if used[field] && value == "" {
+			return nil, errors.Errorf("error rendering template %s: .%s is not known from the f1 command line", name, field)
+		}
+	}
+
+	var out bytes.Buffer
+	err = tmpl.Execute(&out, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error rendering template %s", name)
+	}
+	return out.Bytes(), nil
+}
+
+// missingAsEmpty replaces the missing values, which text/template prints as "<no value>"
+func missingAsEmpty(value interface{}) interface{} {
+	if value == nil {
+		return ""
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return ""
+	}
+	return value
+}
+
+// printMissingAsEmpty pipes the value of every action that prints one into missingAsEmpty
+func printMissingAsEmpty(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			printMissingAsEmpty(tree, child)
+		}
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+		helper := parse.NewIdentifier(missingAsEmptyFunc).SetTree(tree).SetPos(n.Pos)
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{NodeType: parse.NodeCommand, Pos: n.Pos, Args: []parse.Node{helper}})
+	case *parse.IfNode:
+		printMissingAsEmpty(tree, n.List)
+		printMissingAsEmpty(tree, n.ElseList)
+	case *parse.RangeNode:
+		printMissingAsEmpty(tree, n.List)
+		printMissingAsEmpty(tree, n.ElseList)
+	case *parse.WithNode:
+		printMissingAsEmpty(tree, n.List)
+		printMissingAsEmpty(tree, n.ElseList)
+	}
+}
+
+// usedFields collects the fields of the template data the nodes refer to, through $ or through the dot
+// when it is still the template data
+func usedFields(node parse.Node, dotIsData bool, used map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			usedFields(child, dotIsData, used)
+		}
+	case *parse.ActionNode:
+		usedFields(n.Pipe, dotIsData, used)
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			usedFields(n.Pipe, dotIsData, used)
+		}
+	case *parse.IfNode:
+		usedFields(n.Pipe, dotIsData, used)
+		usedFields(n.List, dotIsData, used)
+		usedFields(n.ElseList, dotIsData, used)
+	case *parse.RangeNode:
+		usedFields(n.Pipe, dotIsData, used)
+		usedFields(n.List, false, used)
+		usedFields(n.ElseList, dotIsData, used)
+	case *parse.WithNode:
+		usedFields(n.Pipe, dotIsData, used)
+		usedFields(n.List, false, used)
+		usedFields(n.ElseList, dotIsData, used)
+	case *parse.PipeNode:
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				usedFields(arg, dotIsData, used)
+			}
+		}
+	case *parse.FieldNode:
+		if dotIsData {
+			used[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			used[n.Ident[1]] = true
+		}
+	}
+}
+
+// templateFuncs are a subset of the sprig helpers commonly used in manifests
+var templateFuncs = template.FuncMap{
+	"default":    defaultValue,
+	"required":   required,
+	"empty":      empty,
+	"quote":      func(v interface{}) string { return fmt.Sprintf("%q", fmt.Sprint(v)) },
+	"squote":     func(v interface{}) string { return "'" + fmt.Sprint(v) + "'" },
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"join":       join,
+	"list":       func(items ...interface{}) []interface{} { return items },
+	"dict":       dict,
+	"toYaml":     toYaml,
+	"indent":     indent,
+	"nindent":    func(spaces int, s string) string { return "\n" + indent(spaces, s) },
+	"env":        os.Getenv,
+}
+
+func defaultValue(def interface{}, value ...interface{}) interface{} {
+	if len(value) == 0 || empty(value[0]) {
+		return def
+	}
+	return value[0]
+}
+
+func required(msg string, value interface{}) (interface{}, error) {
+	if empty(value) {
+		return nil, errors.New(msg)
+	}
+	return value, nil
+}
+
+func empty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func join(sep string, items interface{}) string {
+	switch v := items.(type) {
+	case []string:
+		return strings.Join(v, sep)
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, sep)
+	default:
+		return fmt.Sprint(items)
+	}
+}
+
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("dict requires key and value pairs")
+	}
+	d := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		d[fmt.Sprint(pairs[i])] = pairs[i+1]
+	}
+	return d, nil
+}
+
+func toYaml(value interface{}) (string, error) {
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
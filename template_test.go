@@ -0,0 +1,99 @@
+package chaosmesh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	data := TemplateData{
+		Scenario: "scenario",
+		RunID:    "run",
+		Rate:     "10/s",
+		Values: map[string]interface{}{
+			"namespace": "chaos",
+			"targets":   []interface{}{map[string]interface{}{"name": "api"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		manifest string
+		rendered string
+	}{
+		{name: "value", manifest: "namespace: {{ .Values.namespace }}", rendered: "namespace: chaos"},
+		{name: "missing value", manifest: "latency: {{ .Values.latency }}", rendered: "latency: "},
+		{name: "defaulted missing value", manifest: `latency: {{ .Values.latency | default "10ms" }}`, rendered: "latency: 10ms"},
+		{name: "missing value in a condition", manifest: `{{ if .Values.latency }}latency{{ else }}none{{ end }}`, rendered: "none"},
+		{name: "missing value in a range", manifest: `{{ range .Values.targets }}{{ .name }}{{ .port }}{{ end }}`, rendered: "api"},
+		{name: "missing value in a defined template", manifest: `{{ define "port" }}{{ .port }}{{ end }}port: {{ template "port" .Values }}`, rendered: "port: "},
+		{name: "literal text", manifest: "description: <no value>", rendered: "description: <no value>"},
+		{name: "rate", manifest: "rate: {{ .Rate }}", rendered: "rate: 10/s"},
+		{name: "run fields through $", manifest: `{{ range .Values.targets }}{{ $.RunID }}{{ end }}`, rendered: "run"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := renderTemplate("manifest", []byte(tt.manifest), data)
+
+			require.NoError(t, err)
+			require.Equal(t, tt.rendered, string(rendered))
+		})
+	}
+}
+
+func TestRenderTemplateWithUnknownRunFields(t *testing.T) {
+	data := TemplateData{Values: map[string]interface{}{"targets": []interface{}{map[string]interface{}{"Rate": "5/s"}}}}
+
+	tests := []struct {
+		name     string
+		manifest string
+		err      string
+	}{
+		{name: "rate", manifest: "rate: {{ .Rate }}", err: "error rendering template manifest: .Rate is not known from the f1 command line"},
+		{name: "max duration in a condition", manifest: "{{ if .MaxDuration }}{{ end }}", err: "error rendering template manifest: .MaxDuration is not known from the f1 command line"},
+		{name: "rate through $", manifest: "{{ range .Values.targets }}{{ $.Rate }}{{ end }}", err: "error rendering template manifest: .Rate is not known from the f1 command line"},
+		{name: "field of another dot", manifest: "{{ range .Values.targets }}{{ .Rate }}{{ end }}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderTemplate("manifest", []byte(tt.manifest), data)
+
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestRunFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		rate        string
+		maxDuration string
+	}{
+		{name: "long flags", args: []string{"run", "constant", "scenario", "--rate", "10/s", "--max-duration", "1m"}, rate: "10/s", maxDuration: "1m0s"},
+		{name: "short flags", args: []string{"run", "constant", "-r10/s", "-d", "1m", "scenario"}, rate: "10/s", maxDuration: "1m0s"},
+		{name: "flags with equal signs", args: []string{"run", "constant", "scenario", "--rate=10/s", "-d=1m"}, rate: "10/s", maxDuration: "1m0s"},
+		{name: "other flags", args: []string{"--cpuprofile", "cpu.out", "run", "constant", "-v", "-c", "5", "scenario", "-r", "10/s"}, rate: "10/s", maxDuration: "1s"},
+		{name: "defaults", args: []string{"run", "constant", "scenario"}, rate: "1/s", maxDuration: "1s"},
+		{name: "trigger without a rate", args: []string{"run", "ramp", "scenario", "-r", "5s", "-d", "1m"}, rate: "", maxDuration: "1m0s"},
+		{name: "file trigger", args: []string{"run", "file", "config.yaml"}, rate: "", maxDuration: ""},
+		{name: "not a run", args: []string{"scenarios", "ls"}, rate: "", maxDuration: ""},
+		{name: "invalid duration", args: []string{"run", "constant", "scenario", "-d", "soon"}, rate: "", maxDuration: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, maxDuration := runFlags(tt.args)
+
+			require.Equal(t, tt.rate, rate)
+			require.Equal(t, tt.maxDuration, maxDuration)
+		})
+	}
+}
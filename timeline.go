@@ -0,0 +1,140 @@
+package chaosmesh
+
+import (
+	"context"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// splitDelayed separates the experiments applied before the load test from the ones started by the timeline
+func splitDelayed(planned []*plannedExperiment) (immediate []*plannedExperiment, delayed []*plannedExperiment) {
+	for _, p := range planned {
+		if p.opts.startAfter > 0 {
+			delayed = append(delayed, p)
+			continue
+		}
+		immediate = append(immediate, p)
+	}
+	return immediate, delayed
+}
+
+// StartTimeline applies the experiments delayed with StartAfter and removes the ones limited with ActiveFor,
+// relative to now, and tracks the nodes of the workflows, in the background. Failures are reported by RunError.
+func (c *experimentsConfigurator) StartTimeline() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.stopTimeline = cancel
+
+	for _, exp := range c.createdExperiments() {
+		if _, ok := exp.obj.(*chaosmeshv1alpha1.Workflow); ok {
+			c.timelineWg.Add(1)
+			go func(exp *createdExperiment) {
+				defer c.timelineWg.Done()
+				c.trackWorkflow(ctx, exp)
+			}(exp)
+		}
+		if exp.opts.activeFor > 0 {
+			c.timelineWg.Add(1)
+			go func(exp *createdExperiment) {
+				defer c.timelineWg.Done()
+				c.expireExperiment(ctx, exp)
+			}(exp)
+		}
+	}
+
+	for _, p := range c.delayed {
+		c.timelineWg.Add(1)
+		go func(p *plannedExperiment) {
+			defer c.timelineWg.Done()
+			c.startDelayedExperiment(ctx, p)
+		}(p)
+	}
+}
+
+// StopTimeline cancels the pending timeline events and waits for the ones in progress
+func (c *experimentsConfigurator) StopTimeline() {
+	if c.stopTimeline == nil {
+		return
+	}
+	c.stopTimeline()
+	c.timelineWg.Wait()
+}
+
+// RunError reports the failures of the experiments managed in the background while the load test runs
+func (c *experimentsConfigurator) RunError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return utilerrors.NewAggregate(c.runErrs)
+}
+
+func (c *experimentsConfigurator) failRun(err error) {
+	c.t.Logger.Errorf("Chaos experiments failed during the run, err: %s", err)
+	c.mu.Lock()
+	c.runErrs = append(c.runErrs, err)
+	c.mu.Unlock()
+}
+
+func (c *experimentsConfigurator) startDelayedExperiment(ctx context.Context, p *plannedExperiment) {
+	if !sleepContext(ctx, p.opts.startAfter) {
+		return
+	}
+
+	expFriendlyName := generateExperimentFriendlyName(p.gvk.Kind, p.obj.GetNamespace(), p.obj.GetName())
+	c.t.Logger.Infof("Starting chaos experiment %s after %s", expFriendlyName, p.opts.startAfter)
+
+	obj, err := c.createExperiment(p.gvk, p.obj, p.opts)
+	if err != nil {
+		c.failRun(errors.Wrapf(err, "chaos experiment %s could not be started", expFriendlyName))
+		return
+	}
+
+	exp := &createdExperiment{gvk: p.gvk, obj: obj, opts: p.opts}
+	err = c.waitForExperiment(exp.gvk, exp.obj, exp.opts, "ready", c.readinessCheckFor(exp))
+	if err != nil {
+		c.failRun(errors.Wrapf(err, "chaos experiment %s could not be started", expFriendlyName))
+		return
+	}
+
+	if _, ok := exp.obj.(*chaosmeshv1alpha1.Workflow); ok {
+		c.timelineWg.Add(1)
+		go func() {
+			defer c.timelineWg.Done()
+			c.trackWorkflow(ctx, exp)
+		}()
+	}
+
+	if p.opts.activeFor > 0 {
+		c.expireExperiment(ctx, exp)
+	}
+}
+
+func (c *experimentsConfigurator) expireExperiment(ctx context.Context, exp *createdExperiment) {
+	if !sleepContext(ctx, exp.opts.activeFor) {
+		return
+	}
+
+	expFriendlyName := generateExperimentFriendlyName(exp.gvk.Kind, exp.obj.GetNamespace(), exp.obj.GetName())
+	c.t.Logger.Infof("Stopping chaos experiment %s after being active for %s", expFriendlyName, exp.opts.activeFor)
+
+	err := c.deleteExperiment(exp.gvk, exp.obj, exp.opts)
+	if err != nil {
+		c.failRun(errors.Wrapf(err, "chaos experiment %s could not be stopped", expFriendlyName))
+		return
+	}
+	c.untrackCreated(exp.obj)
+}
+
+// sleepContext waits for the given duration, it returns false if the context is done first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
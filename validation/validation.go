@@ -0,0 +1,148 @@
+// Package validation runs the validation of the chaos mesh admission webhooks locally,
+// so experiments can be checked without a cluster.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(chaosmeshv1alpha1.AddToScheme(scheme))
+}
+
+// Validate defaults a copy of the experiment and validates it the same way the chaos mesh webhooks do.
+// The experiment may be a typed chaos mesh object or an unstructured one. Kinds unknown to this version
+// of chaos mesh, introduced by a newer version running in the cluster, are left for the cluster to validate.
+func Validate(obj runtime.Object) (err error) {
+	typed, err := Default(obj)
+	if runtime.IsNotRegisteredError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// some of the chaos mesh validations dereference fields without checking them,
+	// the webhook server recovers from those panics and so do we
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid experiment: %v", r)
+		}
+	}()
+
+	wo, ok := typed.(chaosmeshv1alpha1.WebhookObject)
+	if !ok {
+		// kinds without webhooks, such as PodNetworkChaos, are not validated by chaos mesh either
+		return nil
+	}
+
+	err = wo.ValidateCreate()
+	if err != nil {
+		return err
+	}
+
+	if wf, ok := typed.(*chaosmeshv1alpha1.Workflow); ok {
+		return validateWorkflowSchedules(wf)
+	}
+
+	return nil
+}
+
+// Default returns a typed copy of the experiment with the defaults the chaos mesh webhooks apply on creation.
+// The experiment may be a typed chaos mesh object or an unstructured one.
+func Default(obj runtime.Object) (defaulted runtime.Object, err error) {
+	typed, err := toTyped(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			defaulted, err = nil, fmt.Errorf("invalid experiment: %v", r)
+		}
+	}()
+
+	if wf, ok := typed.(*chaosmeshv1alpha1.Workflow); ok {
+		clearEmptyEmbedChaos(wf)
+	}
+	if wo, ok := typed.(chaosmeshv1alpha1.WebhookObject); ok {
+		wo.Default()
+	}
+	return typed, nil
+}
+
+// toTyped returns a typed deep copy of the object
+func toTyped(obj runtime.Object) (runtime.Object, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return obj.DeepCopyObject(), nil
+	}
+
+	typed, err := scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), typed)
+	if err != nil {
+		return nil, err
+	}
+
+	return typed, nil
+}
+
+// clearEmptyEmbedChaos unsets the inlined chaos of templates without any chaos,
+// the unstructured converter allocates it for every template while json decoding does not
+func clearEmptyEmbedChaos(wf *chaosmeshv1alpha1.Workflow) {
+	for i := range wf.Spec.Templates {
+		t := &wf.Spec.Templates[i]
+		if t.EmbedChaos != nil && reflect.DeepEqual(*t.EmbedChaos, chaosmeshv1alpha1.EmbedChaos{}) {
+			t.EmbedChaos = nil
+		}
+	}
+}
+
+// validateWorkflowSchedules validates the schedule templates of a workflow as schedules,
+// the workflow webhook does not check their cron nor their embedded chaos
+func validateWorkflowSchedules(wf *chaosmeshv1alpha1.Workflow) error {
+	var allErrs field.ErrorList
+	templatesPath := field.NewPath("spec", "templates")
+	for i, t := range wf.Spec.Templates {
+		if t.Type != chaosmeshv1alpha1.TypeSchedule || t.Schedule == nil {
+			continue
+		}
+
+		spec := chaosmeshv1alpha1.ScheduleSpec{
+			Schedule: t.Schedule.Schedule,
+			Type:     t.Schedule.Type,
+			ScheduleItem: chaosmeshv1alpha1.ScheduleItem{
+				EmbedChaos: t.Schedule.EmbedChaos,
+			},
+		}
+		for _, err := range spec.Validate() {
+			err.Field = fmt.Sprintf("%s.schedule.%s", templatesPath.Index(i), strings.TrimPrefix(err.Field, "spec."))
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return allErrs.ToAggregate()
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package validation_test
+
+import (
+	"testing"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/samuel-form3/f1-chaos-mesh/validation"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestValidNetworkChaos(t *testing.T) {
+	err := validation.Validate(networkChaos("100ms"))
+
+	require.NoError(t, err)
+}
+
+func TestInvalidLatency(t *testing.T) {
+	err := validation.Validate(networkChaos("not-a-duration"))
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Latency")
+}
+
+func TestUnstructuredExperiment(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "chaos-mesh.org/v1alpha1",
+		"kind":       "PodChaos",
+		"metadata":   map[string]interface{}{"name": "pod-kill", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"action": "pod-kill",
+			"mode":   "fixed",
+			"value":  "not-a-number",
+		},
+	}}
+
+	err := validation.Validate(u)
+
+	require.Error(t, err)
+}
+
+func TestUnknownKindIsNotValidated(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "chaos-mesh.org/v1alpha1",
+		"kind":       "StatusCheck",
+		"metadata":   map[string]interface{}{"name": "status-check", "namespace": "default"},
+		"spec":       map[string]interface{}{"mode": "Continuous"},
+	}}
+
+	err := validation.Validate(u)
+
+	require.NoError(t, err)
+}
+
+func TestInvalidCronInWorkflowSchedule(t *testing.T) {
+	wf := &chaosmeshv1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "workflow", Namespace: "default"},
+		Spec: chaosmeshv1alpha1.WorkflowSpec{
+			Entry: "entry",
+			Templates: []chaosmeshv1alpha1.Template{
+				{
+					Name:     "entry",
+					Type:     chaosmeshv1alpha1.TypeSerial,
+					Children: []string{"schedule"},
+				},
+				{
+					Name: "schedule",
+					Type: chaosmeshv1alpha1.TypeSchedule,
+					Schedule: &chaosmeshv1alpha1.ChaosOnlyScheduleSpec{
+						Schedule: "not a cron",
+						Type:     chaosmeshv1alpha1.ScheduleTypeNetworkChaos,
+						EmbedChaos: chaosmeshv1alpha1.EmbedChaos{
+							NetworkChaos: &networkChaos("100ms").Spec,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := validation.Validate(wf)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "spec.templates[1].schedule.schedule")
+}
+
+func TestDefault(t *testing.T) {
+	chaos := networkChaos("100ms")
+	chaos.Spec.Delay.Correlation = ""
+
+	defaulted, err := validation.Default(chaos)
+
+	require.NoError(t, err)
+	require.NotSame(t, chaos, defaulted)
+	require.Empty(t, chaos.Spec.Delay.Correlation)
+	require.Equal(t, "0", defaulted.(*chaosmeshv1alpha1.NetworkChaos).Spec.Delay.Correlation)
+}
+
+func networkChaos(latency string) *chaosmeshv1alpha1.NetworkChaos {
+	return &chaosmeshv1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{Name: "network-delay", Namespace: "default"},
+		Spec: chaosmeshv1alpha1.NetworkChaosSpec{
+			Action: chaosmeshv1alpha1.DelayAction,
+			PodSelector: chaosmeshv1alpha1.PodSelector{
+				Mode: chaosmeshv1alpha1.AllMode,
+				Selector: chaosmeshv1alpha1.PodSelectorSpec{
+					GenericSelectorSpec: chaosmeshv1alpha1.GenericSelectorSpec{
+						Namespaces: []string{"default"},
+					},
+				},
+			},
+			TcParameter: chaosmeshv1alpha1.TcParameter{
+				Delay: &chaosmeshv1alpha1.DelaySpec{Latency: latency},
+			},
+		},
+	}
+}
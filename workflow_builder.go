@@ -0,0 +1,362 @@
+package chaosmesh
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// defaultWorkflowEntry names the entry node of a workflow when it is not named explicitly
+const defaultWorkflowEntry = "entry"
+
+// WorkflowBuilder builds a chaos workflow from a tree of nodes, the templates of the workflow
+// are named and wired to their children when it is built
+type WorkflowBuilder struct {
+	name      string
+	namespace string
+	labels    map[string]string
+	entry     *WorkflowNode
+}
+
+// NewWorkflowBuilder creates a builder for a workflow with the given name and namespace
+func NewWorkflowBuilder(name, namespace string) *WorkflowBuilder {
+	return &WorkflowBuilder{name: name, namespace: namespace}
+}
+
+// WithLabels adds labels to the workflow
+func (b *WorkflowBuilder) WithLabels(labels map[string]string) *WorkflowBuilder {
+	if b.labels == nil {
+		b.labels = map[string]string{}
+	}
+	for k, v := range labels {
+		b.labels[k] = v
+	}
+	return b
+}
+
+// Entry sets the node the workflow starts from
+func (b *WorkflowBuilder) Entry(node *WorkflowNode) *WorkflowBuilder {
+	b.entry = node
+	return b
+}
+
+// Build generates the templates of the workflow, it fails if a node is invalid, names clash or a reference is unknown
+func (b *WorkflowBuilder) Build() (*chaosmeshv1alpha1.Workflow, error) {
+	if b.entry == nil {
+		return nil, errors.Errorf("workflow %s has no entry node", b.name)
+	}
+
+	g := &workflowGenerator{names: map[*WorkflowNode]string{}, named: map[string]*WorkflowNode{}, visiting: map[*WorkflowNode]bool{}}
+	g.collectNames(b.entry, map[*WorkflowNode]bool{})
+	if b.entry.name == "" && g.named[defaultWorkflowEntry] == nil {
+		g.assign(b.entry, defaultWorkflowEntry)
+	}
+	entry := g.generate(b.entry)
+
+	if len(g.errs) > 0 {
+		return nil, errors.Wrapf(utilerrors.NewAggregate(g.errs), "invalid workflow %s", b.name)
+	}
+
+	return &chaosmeshv1alpha1.Workflow{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: chaosmeshv1alpha1.GroupVersion.String(),
+			Kind:       chaosmeshv1alpha1.KindWorkflow,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.name,
+			Namespace: b.namespace,
+			Labels:    b.labels,
+		},
+		Spec: chaosmeshv1alpha1.WorkflowSpec{
+			Entry:     entry,
+			Templates: g.templates,
+		},
+	}, nil
+}
+
+// WorkflowNode is a step of a workflow, it becomes a template of the workflow when it is built.
+// A node added at several places of the tree is generated once and referenced by its name.
+type WorkflowNode struct {
+	name     string
+	ref      string
+	template chaosmeshv1alpha1.Template
+	children []*WorkflowNode
+	branches []workflowBranch
+	errs     []error
+}
+
+type workflowBranch struct {
+	target     *WorkflowNode
+	expression string
+}
+
+// Serial runs the children one after the other
+func Serial(children ...*WorkflowNode) *WorkflowNode {
+	return &WorkflowNode{template: chaosmeshv1alpha1.Template{Type: chaosmeshv1alpha1.TypeSerial}, children: children}
+}
+
+// Parallel runs the children at the same time
+func Parallel(children ...*WorkflowNode) *WorkflowNode {
+	return &WorkflowNode{template: chaosmeshv1alpha1.Template{Type: chaosmeshv1alpha1.TypeParallel}, children: children}
+}
+
+// Suspend waits for the given duration before the workflow carries on
+func Suspend(d time.Duration) *WorkflowNode {
+	return (&WorkflowNode{template: chaosmeshv1alpha1.Template{Type: chaosmeshv1alpha1.TypeSuspend}}).Deadline(d)
+}
+
+// Task runs the container in a pod, the branches of the task decide what runs after it
+func Task(container corev1.Container) *WorkflowNode {
+	return &WorkflowNode{template: chaosmeshv1alpha1.Template{
+		Type: chaosmeshv1alpha1.TypeTask,
+		Task: &chaosmeshv1alpha1.Task{Container: &container},
+	}}
+}
+
+// Schedule injects the chaos of the node on the given cron schedule
+func Schedule(cron string, chaos *WorkflowNode) *WorkflowNode {
+	node := &WorkflowNode{template: chaosmeshv1alpha1.Template{Type: chaosmeshv1alpha1.TypeSchedule}}
+	if chaos == nil || chaos.template.EmbedChaos == nil {
+		return node.addError(errors.New("schedule requires a chaos node"))
+	}
+
+	node.template.Schedule = &chaosmeshv1alpha1.ChaosOnlyScheduleSpec{
+		Schedule:   cron,
+		Type:       chaosmeshv1alpha1.ScheduleTemplateType(chaos.template.Type),
+		EmbedChaos: *chaos.template.EmbedChaos,
+	}
+	return node
+}
+
+// Ref refers to the node named elsewhere in the workflow
+func Ref(name string) *WorkflowNode {
+	return &WorkflowNode{ref: name}
+}
+
+// Named sets the name of the template, otherwise it is generated from its type
+func (n *WorkflowNode) Named(name string) *WorkflowNode {
+	n.name = name
+	return n
+}
+
+// Deadline limits how long the node runs, chaos is recovered and suspends resume once it is reached
+func (n *WorkflowNode) Deadline(d time.Duration) *WorkflowNode {
+	deadline := d.String()
+	n.template.Deadline = &deadline
+	return n
+}
+
+// Branch runs the target after the task when the expression holds, an empty expression always holds
+func (n *WorkflowNode) Branch(expression string, target *WorkflowNode) *WorkflowNode {
+	if n.template.Type != chaosmeshv1alpha1.TypeTask {
+		return n.addError(errors.Errorf("branches are not supported by %s nodes", n.template.Type))
+	}
+	n.branches = append(n.branches, workflowBranch{target: target, expression: expression})
+	return n
+}
+
+// ConcurrencyPolicy decides whether a scheduled chaos may start before the previous one has finished
+func (n *WorkflowNode) ConcurrencyPolicy(policy chaosmeshv1alpha1.ConcurrencyPolicy) *WorkflowNode {
+	if n.template.Schedule == nil {
+		return n.addError(errors.Errorf("concurrency policy is not supported by %s nodes", n.template.Type))
+	}
+	n.template.Schedule.ConcurrencyPolicy = policy
+	return n
+}
+
+// HistoryLimit sets how many of the scheduled chaos are kept
+func (n *WorkflowNode) HistoryLimit(limit int) *WorkflowNode {
+	if n.template.Schedule == nil {
+		return n.addError(errors.Errorf("history limit is not supported by %s nodes", n.template.Type))
+	}
+	n.template.Schedule.HistoryLimit = limit
+	return n
+}
+
+func (n *WorkflowNode) addError(err error) *WorkflowNode {
+	n.errs = append(n.errs, err)
+	return n
+}
+
+// Chaos nodes
+
+func AWSChaosNode(spec chaosmeshv1alpha1.AWSChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeAWSChaos, chaosmeshv1alpha1.EmbedChaos{AWSChaos: &spec})
+}
+
+func BlockChaosNode(spec chaosmeshv1alpha1.BlockChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeBlockChaos, chaosmeshv1alpha1.EmbedChaos{BlockChaos: &spec})
+}
+
+func DNSChaosNode(spec chaosmeshv1alpha1.DNSChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeDNSChaos, chaosmeshv1alpha1.EmbedChaos{DNSChaos: &spec})
+}
+
+func GCPChaosNode(spec chaosmeshv1alpha1.GCPChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeGCPChaos, chaosmeshv1alpha1.EmbedChaos{GCPChaos: &spec})
+}
+
+func HTTPChaosNode(spec chaosmeshv1alpha1.HTTPChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeHTTPChaos, chaosmeshv1alpha1.EmbedChaos{HTTPChaos: &spec})
+}
+
+func IOChaosNode(spec chaosmeshv1alpha1.IOChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeIOChaos, chaosmeshv1alpha1.EmbedChaos{IOChaos: &spec})
+}
+
+func JVMChaosNode(spec chaosmeshv1alpha1.JVMChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeJVMChaos, chaosmeshv1alpha1.EmbedChaos{JVMChaos: &spec})
+}
+
+func KernelChaosNode(spec chaosmeshv1alpha1.KernelChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeKernelChaos, chaosmeshv1alpha1.EmbedChaos{KernelChaos: &spec})
+}
+
+func NetworkChaosNode(spec chaosmeshv1alpha1.NetworkChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeNetworkChaos, chaosmeshv1alpha1.EmbedChaos{NetworkChaos: &spec})
+}
+
+func PhysicalMachineChaosNode(spec chaosmeshv1alpha1.PhysicalMachineChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypePhysicalMachineChaos, chaosmeshv1alpha1.EmbedChaos{PhysicalMachineChaos: &spec})
+}
+
+func PodChaosNode(spec chaosmeshv1alpha1.PodChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypePodChaos, chaosmeshv1alpha1.EmbedChaos{PodChaos: &spec})
+}
+
+func StressChaosNode(spec chaosmeshv1alpha1.StressChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeStressChaos, chaosmeshv1alpha1.EmbedChaos{StressChaos: &spec})
+}
+
+func TimeChaosNode(spec chaosmeshv1alpha1.TimeChaosSpec) *WorkflowNode {
+	return chaosNode(chaosmeshv1alpha1.TypeTimeChaos, chaosmeshv1alpha1.EmbedChaos{TimeChaos: &spec})
+}
+
+func chaosNode(templateType chaosmeshv1alpha1.TemplateType, chaos chaosmeshv1alpha1.EmbedChaos) *WorkflowNode {
+	return &WorkflowNode{template: chaosmeshv1alpha1.Template{Type: templateType, EmbedChaos: &chaos}}
+}
+
+// workflowGenerator turns a tree of nodes into the templates of a workflow
+type workflowGenerator struct {
+	templates []chaosmeshv1alpha1.Template
+	// names holds the template name of every node, named the node of every name
+	names    map[*WorkflowNode]string
+	named    map[string]*WorkflowNode
+	visiting map[*WorkflowNode]bool
+	counter  int
+	errs     []error
+}
+
+// collectNames reserves the names given explicitly, so that generated names never clash with them
+func (g *workflowGenerator) collectNames(n *WorkflowNode, seen map[*WorkflowNode]bool) {
+	if n == nil || seen[n] {
+		return
+	}
+	seen[n] = true
+
+	if n.name != "" {
+		if other, ok := g.named[n.name]; ok && other != n {
+			g.errs = append(g.errs, errors.Errorf("template name %q is used by several nodes", n.name))
+		}
+		g.assign(n, n.name)
+	}
+	for _, child := range n.children {
+		g.collectNames(child, seen)
+	}
+	for _, branch := range n.branches {
+		g.collectNames(branch.target, seen)
+	}
+}
+
+func (g *workflowGenerator) assign(n *WorkflowNode, name string) {
+	g.names[n] = name
+	g.named[name] = n
+}
+
+// generate adds the template of the node and of its descendants, it returns the name of the template
+func (g *workflowGenerator) generate(n *WorkflowNode) string {
+	if n == nil {
+		g.errs = append(g.errs, errors.New("node is nil"))
+		return ""
+	}
+
+	if n.ref != "" {
+		if _, ok := g.named[n.ref]; !ok {
+			g.errs = append(g.errs, errors.Errorf("reference to unknown template %q", n.ref))
+		}
+		return n.ref
+	}
+
+	if g.visiting[n] {
+		g.errs = append(g.errs, errors.Errorf("template %q contains itself", g.names[n]))
+		return g.names[n]
+	}
+	if name, ok := g.names[n]; ok && g.generated(name) {
+		return name
+	}
+
+	name := g.nameOf(n)
+	for _, err := range n.errs {
+		g.errs = append(g.errs, errors.Wrapf(err, "template %q", name))
+	}
+
+	g.visiting[n] = true
+	defer delete(g.visiting, n)
+
+	// the template is added before its descendants so that the templates read from the entry down
+	idx := len(g.templates)
+	g.templates = append(g.templates, chaosmeshv1alpha1.Template{})
+
+	template := *n.template.DeepCopy()
+	template.Name = name
+	switch template.Type {
+	case chaosmeshv1alpha1.TypeSerial, chaosmeshv1alpha1.TypeParallel:
+		if len(n.children) == 0 {
+			g.errs = append(g.errs, errors.Errorf("template %q has no children", name))
+		}
+		for _, child := range n.children {
+			template.Children = append(template.Children, g.generate(child))
+		}
+	case chaosmeshv1alpha1.TypeTask:
+		for _, branch := range n.branches {
+			template.ConditionalBranches = append(template.ConditionalBranches, chaosmeshv1alpha1.ConditionalBranch{
+				Target:     g.generate(branch.target),
+				Expression: branch.expression,
+			})
+		}
+	}
+	g.templates[idx] = template
+
+	return name
+}
+
+func (g *workflowGenerator) generated(name string) bool {
+	for _, t := range g.templates {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// nameOf returns the name of the node, generating one from its type when it was not named
+func (g *workflowGenerator) nameOf(n *WorkflowNode) string {
+	if name, ok := g.names[n]; ok {
+		return name
+	}
+
+	for {
+		g.counter++
+		name := fmt.Sprintf("%s-%d", strings.ToLower(string(n.template.Type)), g.counter)
+		if _, taken := g.named[name]; !taken {
+			g.assign(n, name)
+			return name
+		}
+	}
+}
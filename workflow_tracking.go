@@ -0,0 +1,181 @@
+package chaosmesh
+
+import (
+	"context"
+	"fmt"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// workflowNodeFailureReasons are the reasons of node conditions reporting a failure
+var workflowNodeFailureReasons = map[string]bool{
+	chaosmeshv1alpha1.ChaosCRCreateFailed: true,
+	chaosmeshv1alpha1.ChaosCRDeleteFailed: true,
+	chaosmeshv1alpha1.TaskPodSpawnFailed:  true,
+}
+
+// workflowTracker follows the nodes of a workflow while the load test runs
+type workflowTracker struct {
+	c               *experimentsConfigurator
+	exp             *createdExperiment
+	wf              *chaosmeshv1alpha1.Workflow
+	expFriendlyName string
+
+	// nodes holds the last state logged for every node
+	nodes        map[string]string
+	accomplished bool
+}
+
+// trackWorkflow logs the nodes of the workflow as they start and finish, and reports the failed ones
+// according to the failure policy of the experiment, until the workflow is accomplished or the context is done
+func (c *experimentsConfigurator) trackWorkflow(ctx context.Context, exp *createdExperiment) {
+	tr := &workflowTracker{
+		c:               c,
+		exp:             exp,
+		wf:              exp.obj.(*chaosmeshv1alpha1.Workflow),
+		expFriendlyName: generateExperimentFriendlyName(exp.gvk.Kind, exp.obj.GetNamespace(), exp.obj.GetName()),
+		nodes:           map[string]string{},
+	}
+
+	watchCli, canWatch := c.kubeCli.(client.WithWatch)
+	for !tr.accomplished {
+		var nodes chaosmeshv1alpha1.WorkflowNodeList
+		err := c.kubeCli.List(ctx, &nodes, tr.listOptions()...)
+		if err == nil {
+			for i := range nodes.Items {
+				tr.observe(&nodes.Items[i])
+			}
+			if canWatch && !tr.accomplished {
+				tr.watch(ctx, watchCli, nodes.ResourceVersion)
+			}
+		}
+
+		if ctx.Err() != nil || tr.workflowGone(ctx) {
+			return
+		}
+		if !tr.accomplished && !sleepContext(ctx, exp.opts.pollInterval) {
+			return
+		}
+	}
+
+	c.t.Logger.Infof("Chaos workflow %s is accomplished", tr.expFriendlyName)
+}
+
+func (tr *workflowTracker) listOptions() []client.ListOption {
+	return []client.ListOption{
+		client.InNamespace(tr.wf.GetNamespace()),
+		client.MatchingLabels{chaosmeshv1alpha1.LabelWorkflow: tr.wf.GetName()},
+	}
+}
+
+// watch follows the nodes from the given resource version until the watch ends or the workflow is accomplished
+func (tr *workflowTracker) watch(ctx context.Context, watchCli client.WithWatch, resourceVersion string) {
+	opts := append(tr.listOptions(), &client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: resourceVersion}})
+	w, err := watchCli.Watch(ctx, &chaosmeshv1alpha1.WorkflowNodeList{}, opts...)
+	if err != nil {
+		return
+	}
+	defer w.Stop()
+
+	for !tr.accomplished {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+			if ev.Type != watch.Added && ev.Type != watch.Modified {
+				continue
+			}
+			if node, ok := ev.Object.(*chaosmeshv1alpha1.WorkflowNode); ok {
+				tr.observe(node)
+			}
+		}
+	}
+}
+
+// workflowGone reports whether the workflow was deleted, by the timeline or an interrupt
+func (tr *workflowTracker) workflowGone(ctx context.Context) bool {
+	var wf chaosmeshv1alpha1.Workflow
+	err := tr.c.kubeCli.Get(ctx, types.NamespacedName{Namespace: tr.wf.GetNamespace(), Name: tr.wf.GetName()}, &wf)
+	return apierrors.IsNotFound(err)
+}
+
+func (tr *workflowTracker) observe(node *chaosmeshv1alpha1.WorkflowNode) {
+	state, failure := workflowNodeState(node)
+	previous, seen := tr.nodes[node.Name]
+	if seen && previous == state {
+		return
+	}
+	tr.nodes[node.Name] = state
+
+	logger := tr.c.t.Logger
+	if !seen {
+		logger.Infof("Chaos workflow %s node %s (template %s) started", tr.expFriendlyName, node.Name, node.Spec.TemplateName)
+	}
+
+	switch {
+	case failure != "":
+		err := errors.Errorf("chaos workflow %s node %s (template %s) failed, %s", tr.expFriendlyName, node.Name, node.Spec.TemplateName, failure)
+		if tr.exp.opts.workflowNodeFailurePolicy == WarnOnWorkflowNodeFailure {
+			logger.Warnf("%s", err)
+		} else {
+			tr.c.failRun(err)
+		}
+	case state != workflowNodeRunning:
+		logger.Infof("Chaos workflow %s node %s (template %s) finished, %s", tr.expFriendlyName, node.Name, node.Spec.TemplateName, state)
+	}
+
+	// the workflow is accomplished once its entry node is
+	if node.Spec.TemplateName == tr.wf.Spec.Entry && workflowNodeHasCondition(node, chaosmeshv1alpha1.ConditionAccomplished) {
+		tr.accomplished = true
+	}
+}
+
+const workflowNodeRunning = "running"
+
+// workflowNodeState describes the state of a node and the failure it reports, if any
+func workflowNodeState(node *chaosmeshv1alpha1.WorkflowNode) (string, string) {
+	for _, cond := range node.Status.Conditions {
+		if workflowNodeFailureReasons[cond.Reason] {
+			return fmt.Sprintf("failed, %s", cond.Reason), cond.Reason
+		}
+	}
+
+	switch {
+	case workflowNodeHasCondition(node, chaosmeshv1alpha1.ConditionAccomplished):
+		return "accomplished", ""
+	case workflowNodeHasCondition(node, chaosmeshv1alpha1.ConditionDeadlineExceed):
+		return "deadline exceeded", ""
+	default:
+		return workflowNodeRunning, ""
+	}
+}
+
+func workflowNodeHasCondition(node *chaosmeshv1alpha1.WorkflowNode, condition chaosmeshv1alpha1.WorkflowNodeConditionType) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == condition && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForWorkflowCompletion blocks the cleanup of a workflow until it is accomplished, within the cleanup timeout
+func (c *experimentsConfigurator) waitForWorkflowCompletion(wf *chaosmeshv1alpha1.Workflow, opts *experimentOptions) error {
+	expFriendlyName := generateExperimentFriendlyName(chaosmeshv1alpha1.KindWorkflow, wf.GetNamespace(), wf.GetName())
+	c.t.Logger.Infof("Waiting for chaos workflow %s to be accomplished (cleanup timeout: %s)", expFriendlyName, opts.cleanupTimeout)
+
+	gvk := chaosmeshv1alpha1.GroupVersion.WithKind(chaosmeshv1alpha1.KindWorkflow)
+	check := workflowConditionsTrue([]chaosmeshv1alpha1.WorkflowConditionType{chaosmeshv1alpha1.WorkflowConditionAccomplished})
+	err := c.waitUntil(gvk, wf, opts.cleanupTimeout, opts.pollInterval, check, true)
+	return errors.Wrap(err, "was not accomplished")
+}
@@ -0,0 +1,89 @@
+package chaosmesh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chaosmeshv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFailedWorkflowNodesAreReportedByPolicy(t *testing.T) {
+	tests := []struct {
+		policy WorkflowNodeFailurePolicy
+		failed bool
+	}{
+		{policy: FailOnWorkflowNodeFailure, failed: true},
+		{policy: WarnOnWorkflowNodeFailure, failed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			wf := workflowObject("tracked")
+			kubeCli := &plainClient{Client: fake.NewClientBuilder().WithScheme(experimentsScheme).WithObjects(
+				wf,
+				workflowNodeObject(wf, "entry-node", "entry", chaosmeshv1alpha1.WorkflowNodeCondition{
+					Type: chaosmeshv1alpha1.ConditionAccomplished, Status: corev1.ConditionTrue,
+				}),
+				workflowNodeObject(wf, "delay-node", "delay", chaosmeshv1alpha1.WorkflowNodeCondition{
+					Type: chaosmeshv1alpha1.ConditionChaosInjected, Status: corev1.ConditionFalse, Reason: chaosmeshv1alpha1.ChaosCRCreateFailed,
+				}),
+			).Build()}
+			c := newTestConfigurator(t, kubeCli)
+
+			opts := defaultExperimentOptions()
+			WithWorkflowNodeFailurePolicy(tt.policy)(&opts)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			c.trackWorkflow(ctx, &createdExperiment{gvk: wf.GroupVersionKind(), obj: wf, opts: &opts})
+			require.NoError(t, ctx.Err(), "the workflow was not seen accomplished")
+
+			if !tt.failed {
+				require.NoError(t, c.RunError())
+				return
+			}
+			require.EqualError(t, c.RunError(),
+				"chaos workflow [Workflow]::default/tracked node delay-node (template delay) failed, "+chaosmeshv1alpha1.ChaosCRCreateFailed)
+		})
+	}
+}
+
+func TestWorkflowTrackingEndsWhenTheWorkflowIsGone(t *testing.T) {
+	wf := workflowObject("gone")
+	kubeCli := &plainClient{Client: fake.NewClientBuilder().WithScheme(experimentsScheme).Build()}
+	c := newTestConfigurator(t, kubeCli)
+	opts := defaultExperimentOptions()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c.trackWorkflow(ctx, &createdExperiment{gvk: wf.GroupVersionKind(), obj: wf, opts: &opts})
+
+	require.NoError(t, ctx.Err(), "the tracking did not end")
+	require.NoError(t, c.RunError())
+}
+
+func workflowObject(name string) *chaosmeshv1alpha1.Workflow {
+	return &chaosmeshv1alpha1.Workflow{
+		TypeMeta:   metav1.TypeMeta{APIVersion: chaosmeshv1alpha1.GroupVersion.String(), Kind: chaosmeshv1alpha1.KindWorkflow},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       chaosmeshv1alpha1.WorkflowSpec{Entry: "entry"},
+	}
+}
+
+func workflowNodeObject(wf *chaosmeshv1alpha1.Workflow, name, template string, condition chaosmeshv1alpha1.WorkflowNodeCondition) client.Object {
+	return &chaosmeshv1alpha1.WorkflowNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: wf.Namespace,
+			Labels:    map[string]string{chaosmeshv1alpha1.LabelWorkflow: wf.Name},
+		},
+		Spec:   chaosmeshv1alpha1.WorkflowNodeSpec{WorkflowName: wf.Name, TemplateName: template},
+		Status: chaosmeshv1alpha1.WorkflowNodeStatus{Conditions: []chaosmeshv1alpha1.WorkflowNodeCondition{condition}},
+	}
+}